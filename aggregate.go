@@ -0,0 +1,78 @@
+package set
+
+// Number is a constraint permitting the integer and floating-point types.
+type Number interface {
+	Integer | ~float32 | ~float64
+}
+
+// Sum returns the sum of the values of `s`, computed in `V` so that integers
+// wrap around on overflow. It returns 0 if `s` is empty.
+//
+// For example:
+//
+//	s = {1, 2, 3}
+//	set.Sum(s) = 6
+func Sum[V Number](s *Set[V]) V {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var sum V
+	for k := range s.values() {
+		sum += k
+	}
+
+	return sum
+}
+
+// Mean returns the arithmetic mean of the values of `s`, computed in float64,
+// and false if `s` is empty.
+//
+// For example:
+//
+//	s = {1, 2, 3, 4}
+//	set.Mean(s) = 2.5, true
+func Mean[V Number](s *Set[V]) (float64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	n := s.size()
+	if n == 0 {
+		return 0, false
+	}
+
+	var sum float64
+	for k := range s.values() {
+		sum += float64(k)
+	}
+
+	return sum / float64(n), true
+}
+
+// MinMax returns the smallest and largest values of `s`, and false if `s` is
+// empty. As with the min and max built-in functions, they are NaN if `s`
+// contains a NaN.
+//
+// For example:
+//
+//	s = {3, 1, 2}
+//	set.MinMax(s) = 1, 3, true
+func MinMax[V Number](s *Set[V]) (lo, hi V, _ bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.size() == 0 {
+		return lo, hi, false
+	}
+
+	first := true
+	for k := range s.values() {
+		if first {
+			lo, hi, first = k, k, false
+			continue
+		}
+
+		lo, hi = min(lo, k), max(hi, k)
+	}
+
+	return lo, hi, true
+}
@@ -0,0 +1,161 @@
+package set_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestSum(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.Set[int8]
+		want int8
+	}{
+		{
+			name: "empty",
+			s:    set.New[int8](),
+			want: 0,
+		},
+		{
+			name: "values",
+			s:    set.New[int8](1, 2, 3, -4),
+			want: 2,
+		},
+		{
+			name: "overflow",
+			s:    set.New[int8](100, 28),
+			want: math.MinInt8,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, set.Sum(tt.s)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMean(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		s      *set.Set[uint8]
+		want   float64
+		wantOK bool
+	}{
+		{
+			name:   "empty",
+			s:      set.New[uint8](),
+			want:   0,
+			wantOK: false,
+		},
+		{
+			name:   "values",
+			s:      set.New[uint8](1, 2, 3, 4),
+			want:   2.5,
+			wantOK: true,
+		},
+		{
+			name:   "no overflow",
+			s:      set.New[uint8](255, 253),
+			want:   254,
+			wantOK: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := set.Mean(tt.s)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantOK, ok); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		s      *set.Set[float64]
+		wantLo float64
+		wantHi float64
+		wantOK bool
+	}{
+		{
+			name:   "empty",
+			s:      set.New[float64](),
+			wantOK: false,
+		},
+		{
+			name:   "single value",
+			s:      set.New(1.5),
+			wantLo: 1.5,
+			wantHi: 1.5,
+			wantOK: true,
+		},
+		{
+			name:   "values",
+			s:      rangeFloats(-50, 50),
+			wantLo: -50,
+			wantHi: 49,
+			wantOK: true,
+		},
+		{
+			name:   "nan",
+			s:      set.New(1, math.NaN(), 3),
+			wantLo: math.NaN(),
+			wantHi: math.NaN(),
+			wantOK: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			lo, hi, ok := set.MinMax(tt.s)
+			if diff := cmp.Diff([]float64{tt.wantLo, tt.wantHi}, []float64{lo, hi}, cmp.Comparer(func(x, y float64) bool {
+				return x == y || math.IsNaN(x) && math.IsNaN(y)
+			})); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantOK, ok); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// rangeFloats returns a Set of the integers in [lo, hi) as floats.
+func rangeFloats(lo, hi int) *set.Set[float64] {
+	s := set.New[float64]()
+	for i := lo; i < hi; i++ {
+		s.Insert(float64(i))
+	}
+
+	return s
+}
@@ -0,0 +1,88 @@
+package set
+
+import (
+	"hash/maphash"
+	"math"
+)
+
+// BloomFilter is a probabilistic membership filter of comparables.
+//
+// A BloomFilter never reports a false negative: MayContain returns true for
+// every value that has been added. It may however report a false positive
+// with a probability close to the rate it was sized for.
+//
+// Unlike Set, a BloomFilter is not safe for concurrent use.
+type BloomFilter[V comparable] struct {
+	bits  []uint64
+	m     uint64
+	k     uint64
+	seeds [2]maphash.Seed
+}
+
+// NewBloomFilter returns an empty BloomFilter sized to hold `n` values with a
+// false-positive rate of about `p`.
+//
+// NewBloomFilter panics if `p` is not in the open interval (0, 1).
+func NewBloomFilter[V comparable](n int, p float64) *BloomFilter[V] {
+	if p <= 0 || p >= 1 {
+		panic("set: false-positive rate must be in (0, 1)")
+	}
+	if n < 1 {
+		n = 1
+	}
+
+	m := math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2))
+	k := math.Max(1, math.Round(m/float64(n)*math.Ln2))
+
+	return &BloomFilter[V]{
+		bits:  make([]uint64, (uint64(m)+63)/64),
+		m:     uint64(m),
+		k:     uint64(k),
+		seeds: [2]maphash.Seed{maphash.MakeSeed(), maphash.MakeSeed()},
+	}
+}
+
+// BloomFilter returns a BloomFilter containing the values of `s`, sized for
+// the current length of `s` and a false-positive rate of about `p`.
+func (s *Set[V]) BloomFilter(p float64) *BloomFilter[V] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	f := NewBloomFilter[V](s.size(), p)
+
+	for k := range s.values() {
+		f.Add(k)
+	}
+
+	return f
+}
+
+// Add adds the given values to `f`.
+func (f *BloomFilter[V]) Add(v ...V) {
+	for _, x := range v {
+		h1, h2 := f.hash(x)
+		for i := uint64(0); i < f.k; i++ {
+			j := (h1 + i*h2) % f.m
+			f.bits[j/64] |= 1 << (j % 64)
+		}
+	}
+}
+
+// MayContain returns false if `v` has definitely not been added to `f`, and
+// true if it probably has.
+func (f *BloomFilter[V]) MayContain(v V) bool {
+	h1, h2 := f.hash(v)
+	for i := uint64(0); i < f.k; i++ {
+		j := (h1 + i*h2) % f.m
+		if f.bits[j/64]&(1<<(j%64)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hash returns the two hashes of `v` used for double hashing.
+func (f *BloomFilter[V]) hash(v V) (uint64, uint64) {
+	return maphash.Comparable(f.seeds[0], v), maphash.Comparable(f.seeds[1], v) | 1
+}
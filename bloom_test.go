@@ -0,0 +1,78 @@
+package set_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestBloomFilterMayContain(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.Set[int]
+		v    int
+		want bool
+	}{
+		{
+			name: "contains",
+			s:    set.New(1, 2, 3),
+			v:    2,
+			want: true,
+		},
+		{
+			name: "empty",
+			s:    set.New[int](),
+			v:    1,
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := tt.s.BloomFilter(0.01)
+
+			if diff := cmp.Diff(tt.want, f.MayContain(tt.v)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBloomFilterFalsePositiveRate(t *testing.T) {
+	t.Parallel()
+
+	const (
+		n = 10000
+		p = 0.01
+	)
+
+	f := set.NewBloomFilter[int](n, p)
+	for i := 0; i < n; i++ {
+		f.Add(i)
+	}
+
+	for i := 0; i < n; i++ {
+		if !f.MayContain(i) {
+			t.Fatalf("false negative for %d", i)
+		}
+	}
+
+	var fp int
+	for i := n; i < 2*n; i++ {
+		if f.MayContain(i) {
+			fp++
+		}
+	}
+
+	if rate := float64(fp) / n; rate > 2*p {
+		t.Errorf("false-positive rate %v exceeds %v", rate, 2*p)
+	}
+}
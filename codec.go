@@ -0,0 +1,26 @@
+package set
+
+// Codec encodes values of type V to bytes and back.
+//
+// Encodings must be canonical: two values are equal iff their encodings are,
+// since encoded values are compared byte by byte.
+type Codec[V any] interface {
+	// Append appends the encoding of `v` to `dst` and returns the extended
+	// slice.
+	Append(dst []byte, v V) []byte
+	// Decode returns the value encoded by `b`. It must not retain `b`.
+	Decode(b []byte) (V, error)
+}
+
+// StringCodec is a Codec encoding strings as their bytes.
+type StringCodec struct{}
+
+// Append implements Codec.
+func (StringCodec) Append(dst []byte, v string) []byte {
+	return append(dst, v...)
+}
+
+// Decode implements Codec.
+func (StringCodec) Decode(b []byte) (string, error) {
+	return string(b), nil
+}
@@ -0,0 +1,82 @@
+package set
+
+import (
+	"iter"
+)
+
+// MaxPowerSetLen is the maximum length of a Set accepted by PowerSet.
+const MaxPowerSetLen = 63
+
+// PowerSet returns an iterator over all the subsets of `s`, starting with the
+// empty set.
+//
+// A Set of length n has 2^n subsets, so callers should stop the iteration
+// early unless `s` is small. PowerSet panics if `s` has more than
+// MaxPowerSetLen values.
+//
+// The subsets are made of the values of `s` when PowerSet is called, and
+// changes to `s` made afterwards are ignored, unlike Product, which reads its
+// sets during the iteration.
+//
+// For example:
+//
+//	s = {a1, a2}
+//	set.PowerSet(s) = {}, {a1}, {a2}, {a1, a2}
+func PowerSet[V comparable](s *Set[V]) iter.Seq[*Set[V]] {
+	v := s.Values()
+	if len(v) > MaxPowerSetLen {
+		panic("set: too many values for PowerSet")
+	}
+
+	return func(yield func(*Set[V]) bool) {
+		for mask := uint64(0); mask < 1<<len(v); mask++ {
+			t := New[V]()
+			for i, x := range v {
+				if mask&(1<<i) != 0 {
+					t.Insert(x)
+				}
+			}
+
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// Pair is an ordered pair of comparables.
+type Pair[A, B comparable] struct {
+	First  A
+	Second B
+}
+
+// Product returns an iterator over all the pairs of values of `a` and `b`,
+// that is the Cartesian product of `a` and `b`.
+//
+// For example:
+//
+//	a = {a1, a2}
+//	b = {b1}
+//	set.Product(a, b) = (a1, b1), (a2, b1)
+func Product[A, B comparable](a *Set[A], b *Set[B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		for x := range a.All() {
+			for y := range b.All() {
+				if !yield(x, y) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ProductSet returns a new Set of all the pairs of values of `a` and `b`.
+func ProductSet[A, B comparable](a *Set[A], b *Set[B]) *Set[Pair[A, B]] {
+	u := newSized[Pair[A, B]](a.Len() * b.Len())
+
+	for x, y := range Product(a, b) {
+		u.Insert(Pair[A, B]{x, y})
+	}
+
+	return u
+}
@@ -0,0 +1,156 @@
+package set_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestPowerSet(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.Set[int]
+		want []*set.Set[int]
+	}{
+		{
+			name: "power set",
+			s:    set.New(1, 2, 3),
+			want: []*set.Set[int]{
+				set.New[int](),
+				set.New(1), set.New(2), set.New(3),
+				set.New(1, 2), set.New(1, 3), set.New(2, 3),
+				set.New(1, 2, 3),
+			},
+		},
+		{
+			name: "power set of empty",
+			s:    set.New[int](),
+			want: []*set.Set[int]{set.New[int]()},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, slices.Collect(set.PowerSet(tt.s)), cmp.Comparer(equal(t)), cmpopts.SortSlices(func(s1, s2 *set.Set[int]) bool {
+				return slices.Compare(sortedValues(s1), sortedValues(s2)) < 0
+			})); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPowerSetTooLarge(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("PowerSet did not panic")
+		}
+	}()
+
+	set.PowerSet(rangeSet(0, set.MaxPowerSetLen+1))
+}
+
+func TestPowerSetSnapshot(t *testing.T) {
+	t.Parallel()
+
+	s := set.New(1)
+	subsets := set.PowerSet(s)
+	s.Insert(2)
+
+	want := []*set.Set[int]{set.New[int](), set.New(1)}
+
+	if diff := cmp.Diff(want, slices.Collect(subsets), cmp.Comparer(equal(t))); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
+
+func TestProduct(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		a    *set.Set[int]
+		b    *set.Set[string]
+		want []set.Pair[int, string]
+	}{
+		{
+			name: "product",
+			a:    set.New(1, 2),
+			b:    set.New("a", "b"),
+			want: []set.Pair[int, string]{{1, "a"}, {1, "b"}, {2, "a"}, {2, "b"}},
+		},
+		{
+			name: "product with empty",
+			a:    set.New(1, 2),
+			b:    set.New[string](),
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []set.Pair[int, string]
+			for x, y := range set.Product(tt.a, tt.b) {
+				got = append(got, set.Pair[int, string]{x, y})
+			}
+
+			if diff := cmp.Diff(tt.want, got, cmpopts.SortSlices(comparePairs)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProductSet(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		a    *set.Set[int]
+		b    *set.Set[string]
+		want *set.Set[set.Pair[int, string]]
+	}{
+		{
+			name: "product set",
+			a:    set.New(1, 2),
+			b:    set.New("a"),
+			want: set.New(set.Pair[int, string]{1, "a"}, set.Pair[int, string]{2, "a"}),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, set.ProductSet(tt.a, tt.b)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func comparePairs(p1, p2 set.Pair[int, string]) bool {
+	if p1.First != p2.First {
+		return p1.First < p2.First
+	}
+
+	return p1.Second < p2.Second
+}
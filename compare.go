@@ -0,0 +1,35 @@
+package set
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+)
+
+// compareValues compares values for sorting them in a canonical order.
+//
+// Integers, floats and strings are compared by value; other types are compared
+// by their Go-syntax representation. Values of different kinds, as in a
+// Set[any], are compared by kind first.
+func compareValues[V comparable](a, b V) int {
+	x, y := reflect.ValueOf(a), reflect.ValueOf(b)
+
+	// Values of an interface type may have different kinds, or be nil with
+	// the invalid kind, which sort before the others.
+	if c := cmp.Compare(x.Kind(), y.Kind()); c != 0 {
+		return c
+	}
+
+	switch x.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(x.Int(), y.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(x.Uint(), y.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(x.Float(), y.Float())
+	case reflect.String:
+		return cmp.Compare(x.String(), y.String())
+	default:
+		return cmp.Compare(fmt.Sprintf("%#v", a), fmt.Sprintf("%#v", b))
+	}
+}
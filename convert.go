@@ -0,0 +1,102 @@
+package set
+
+import (
+	"maps"
+)
+
+// FromMapKeys returns a Set of the keys of `m`.
+func FromMapKeys[K comparable, V any](m map[K]V) *Set[K] {
+	s := newSized[K](len(m))
+
+	for k := range m {
+		s.Insert(k)
+	}
+
+	return s
+}
+
+// FromMapValues returns a Set of the values of `m`.
+func FromMapValues[K, V comparable](m map[K]V) *Set[V] {
+	s := New[V]()
+
+	for _, v := range m {
+		s.Insert(v)
+	}
+
+	return s
+}
+
+// Collect returns a Set of the results of calling `f` on each element of `in`.
+//
+// For example:
+//
+//	in = [{ID: 1}, {ID: 2}, {ID: 1}]
+//	set.Collect(in, func(x T) int { return x.ID }) = {1, 2}
+func Collect[T any, V comparable](in []T, f func(T) V) *Set[V] {
+	s := New[V]()
+
+	for _, x := range in {
+		s.Insert(f(x))
+	}
+
+	return s
+}
+
+// Convert returns a Set of the results of calling `f` on each value of `s`.
+//
+// Convert stops at the first error returned by `f` and returns it with a nil
+// Set. `s` is read-locked while `f` is called, so `f` must not modify `s`.
+//
+// For example:
+//
+//	s = {"1", "2", "01"}
+//	set.Convert(s, strconv.Atoi) = {1, 2}, nil
+func Convert[V, U comparable](s *Set[V], f func(V) (U, error)) (*Set[U], error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	t := newSized[U](s.size())
+
+	for k := range ordered(s.values()) {
+		u, err := f(k)
+		if err != nil {
+			return nil, err
+		}
+
+		t.insert(u)
+	}
+
+	return t, nil
+}
+
+// ToMap returns a map from the values of `s` to the result of calling `f` on
+// each of them, under one read lock of `s`, so `f` must not modify `s`.
+func ToMap[V comparable, T any](s *Set[V], f func(V) T) map[V]T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	m := make(map[V]T, s.size())
+
+	for k := range s.values() {
+		m[k] = f(k)
+	}
+
+	return m
+}
+
+// Map returns the values of `s` as the keys of a new map.
+func (s *Set[V]) Map() map[V]struct{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.m != nil {
+		return maps.Clone(s.m)
+	}
+
+	m := make(map[V]struct{}, len(s.small))
+	for _, k := range s.small {
+		m[k] = struct{}{}
+	}
+
+	return m
+}
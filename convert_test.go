@@ -0,0 +1,223 @@
+package set_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestFromMapKeys(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		m    map[int]string
+		want *set.Set[int]
+	}{
+		{
+			name: "from map keys",
+			m:    map[int]string{1: "a", 2: "b"},
+			want: set.New(1, 2),
+		},
+		{
+			name: "nil map",
+			want: set.New[int](),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, set.FromMapKeys(tt.m)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFromMapValues(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		m    map[string]int
+		want *set.Set[int]
+	}{
+		{
+			name: "from map values",
+			m:    map[string]int{"a": 1, "b": 2, "c": 1},
+			want: set.New(1, 2),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, set.FromMapValues(tt.m)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCollect(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		ID   int
+		Name string
+	}
+
+	tests := []struct {
+		name string
+		in   []user
+		want *set.Set[int]
+	}{
+		{
+			name: "collect",
+			in:   []user{{1, "foo"}, {2, "bar"}, {1, "baz"}},
+			want: set.New(1, 2),
+		},
+		{
+			name: "empty",
+			want: set.New[int](),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := set.Collect(tt.in, func(u user) int { return u.ID })
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		s       *set.Set[string]
+		want    *set.Set[int]
+		wantErr bool
+	}{
+		{
+			name: "convert",
+			s:    set.New("1", "2", "01"),
+			want: set.New(1, 2),
+		},
+		{
+			name:    "convert error",
+			s:       set.New("1", "a"),
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := set.Convert(tt.s, strconv.Atoi)
+
+			if diff := cmp.Diff(tt.wantErr, err != nil); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("got %v, want nil", got)
+				}
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestToMap(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.Set[int]
+		want map[int]string
+	}{
+		{
+			name: "to map",
+			s:    set.New(1, 2),
+			want: map[int]string{1: "1", 2: "2"},
+		},
+		{
+			name: "empty",
+			s:    set.New[int](),
+			want: map[int]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, set.ToMap(tt.s, strconv.Itoa)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetMap(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.Set[int]
+		want map[int]struct{}
+	}{
+		{
+			name: "map",
+			s:    set.New(1, 2),
+			want: map[int]struct{}{1: {}, 2: {}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tt.s.Map()
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+
+			delete(got, 1)
+			if !tt.s.Contains(1) {
+				t.Error("Map returned the underlying map")
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package set
+
+import (
+	"hash/maphash"
+	"math/bits"
+	"math/rand/v2"
+)
+
+const (
+	cuckooBucketSize = 4
+	cuckooMaxKicks   = 500
+)
+
+type cuckooBucket [cuckooBucketSize]uint16
+
+// CuckooFilter is a probabilistic membership filter of comparables that, unlike
+// BloomFilter, supports deletion.
+//
+// Each value is stored as a 16-bit fingerprint, so a CuckooFilter uses about
+// two bytes per value and reports false positives at a rate of about 0.01%.
+// Unlike Set, a CuckooFilter is not safe for concurrent use.
+type CuckooFilter[V comparable] struct {
+	buckets []cuckooBucket
+	mask    uint64
+	seed    maphash.Seed
+	length  int
+
+	// victim holds a fingerprint evicted by a failed insertion so that it is
+	// not lost; a filter with a victim is full.
+	victim      uint16
+	victimIndex uint64
+}
+
+// NewCuckooFilter returns an empty CuckooFilter with room for at least `n`
+// values.
+func NewCuckooFilter[V comparable](n int) *CuckooFilter[V] {
+	b := uint64(n)/cuckooBucketSize*100/95 + 1
+	b = 1 << bits.Len64(b-1)
+
+	return &CuckooFilter[V]{
+		buckets: make([]cuckooBucket, b),
+		mask:    b - 1,
+		seed:    maphash.MakeSeed(),
+	}
+}
+
+// CuckooFilter returns a CuckooFilter containing the values of `s`, sized for
+// the current length of `s`.
+func (s *Set[V]) CuckooFilter() *CuckooFilter[V] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	f := NewCuckooFilter[V](s.size())
+
+	for k := range s.values() {
+		f.Add(k)
+	}
+
+	return f
+}
+
+// Add adds `v` to `f`. It returns false if `f` is full.
+//
+// Adding the same value twice stores it twice, and it then has to be deleted
+// twice.
+func (f *CuckooFilter[V]) Add(v V) bool {
+	if f.victim != 0 {
+		return false
+	}
+
+	fp, i1, i2 := f.index(v)
+	f.insert(fp, i1, i2)
+	f.length++
+
+	return true
+}
+
+// insert stores `fp` in bucket `i1` or `i2`, relocating other fingerprints as
+// needed. A fingerprint that cannot be placed is kept as the victim.
+func (f *CuckooFilter[V]) insert(fp uint16, i1, i2 uint64) {
+	if f.buckets[i1].insert(fp) || f.buckets[i2].insert(fp) {
+		return
+	}
+
+	i := i1
+	if rand.IntN(2) == 0 {
+		i = i2
+	}
+
+	for n := 0; n < cuckooMaxKicks; n++ {
+		j := rand.IntN(cuckooBucketSize)
+		fp, f.buckets[i][j] = f.buckets[i][j], fp
+		i = f.altIndex(i, fp)
+
+		if f.buckets[i].insert(fp) {
+			return
+		}
+	}
+
+	f.victim, f.victimIndex = fp, i
+}
+
+// MayContain returns false if `v` is definitely not in `f`, and true if it
+// probably is.
+func (f *CuckooFilter[V]) MayContain(v V) bool {
+	fp, i1, i2 := f.index(v)
+
+	if f.victim == fp && (f.victimIndex == i1 || f.victimIndex == i2) {
+		return true
+	}
+
+	return f.buckets[i1].contains(fp) || f.buckets[i2].contains(fp)
+}
+
+// Delete removes `v` from `f`. It returns false if `v` was not found.
+//
+// Delete must only be called with values that have been added to `f`;
+// deleting any other value may remove a colliding value's fingerprint and
+// introduce false negatives.
+func (f *CuckooFilter[V]) Delete(v V) bool {
+	fp, i1, i2 := f.index(v)
+
+	switch {
+	case f.victim == fp && (f.victimIndex == i1 || f.victimIndex == i2):
+		f.victim = 0
+	case f.buckets[i1].delete(fp), f.buckets[i2].delete(fp):
+		if f.victim != 0 {
+			fp, i := f.victim, f.victimIndex
+			f.victim = 0
+			f.insert(fp, i, f.altIndex(i, fp))
+		}
+	default:
+		return false
+	}
+
+	f.length--
+
+	return true
+}
+
+// Len returns the number of values in `f`.
+func (f *CuckooFilter[V]) Len() int {
+	return f.length
+}
+
+// index returns the fingerprint of `v` and its two candidate bucket indexes.
+func (f *CuckooFilter[V]) index(v V) (uint16, uint64, uint64) {
+	h := maphash.Comparable(f.seed, v)
+
+	fp := uint16(h >> 48)
+	if fp == 0 {
+		fp = 1
+	}
+
+	i := h & f.mask
+
+	return fp, i, f.altIndex(i, fp)
+}
+
+// altIndex returns the other candidate bucket index of fingerprint `fp` stored
+// in bucket `i`.
+func (f *CuckooFilter[V]) altIndex(i uint64, fp uint16) uint64 {
+	return (i ^ uint64(fp)*0x5bd1e995) & f.mask
+}
+
+func (b *cuckooBucket) insert(fp uint16) bool {
+	for i := range b {
+		if b[i] == 0 {
+			b[i] = fp
+			return true
+		}
+	}
+
+	return false
+}
+
+func (b *cuckooBucket) contains(fp uint16) bool {
+	for i := range b {
+		if b[i] == fp {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (b *cuckooBucket) delete(fp uint16) bool {
+	for i := range b {
+		if b[i] == fp {
+			b[i] = 0
+			return true
+		}
+	}
+
+	return false
+}
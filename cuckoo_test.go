@@ -0,0 +1,119 @@
+package set_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestCuckooFilterMayContain(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.Set[int]
+		v    int
+		want bool
+	}{
+		{
+			name: "contains",
+			s:    set.New(1, 2, 3),
+			v:    2,
+			want: true,
+		},
+		{
+			name: "empty",
+			s:    set.New[int](),
+			v:    1,
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := tt.s.CuckooFilter()
+
+			if diff := cmp.Diff(tt.want, f.MayContain(tt.v)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCuckooFilterDelete(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		s       *set.Set[int]
+		v       int
+		want    bool
+		wantLen int
+	}{
+		{
+			name:    "delete",
+			s:       set.New(1, 2, 3),
+			v:       2,
+			want:    true,
+			wantLen: 2,
+		},
+		{
+			name:    "not found",
+			s:       set.New(1, 2, 3),
+			v:       4,
+			want:    false,
+			wantLen: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := tt.s.CuckooFilter()
+
+			if diff := cmp.Diff(tt.want, f.Delete(tt.v)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(false, f.MayContain(tt.v)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantLen, f.Len()); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCuckooFilterCapacity(t *testing.T) {
+	t.Parallel()
+
+	const n = 10000
+
+	f := set.NewCuckooFilter[int](n)
+	for i := 0; i < n; i++ {
+		if !f.Add(i) {
+			t.Fatalf("filter full after %d values", i)
+		}
+	}
+
+	for i := 0; i < n; i += 2 {
+		if !f.Delete(i) {
+			t.Fatalf("failed to delete %d", i)
+		}
+	}
+
+	for i := 1; i < n; i += 2 {
+		if !f.MayContain(i) {
+			t.Fatalf("false negative for %d", i)
+		}
+	}
+}
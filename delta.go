@@ -0,0 +1,108 @@
+package set
+
+import (
+	"encoding/binary"
+	"errors"
+	"slices"
+)
+
+// Integer is a constraint permitting the integer types.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// errCorruptDeltas is returned by DecodeDeltas for invalid input.
+var errCorruptDeltas = errors.New("set: corrupt delta encoding")
+
+// AppendDeltas appends a compact encoding of the integers of `s` to `dst` and
+// returns the extended slice.
+//
+// The integers are sorted, and the encoding holds their number, the smallest
+// one and then the differences between consecutive ones, each as a varint.
+// Sets of close integers, such as IDs, then take one or two bytes per value.
+func AppendDeltas[V Integer](dst []byte, s *Set[V]) []byte {
+	s.mu.RLock()
+	v := slices.Sorted(s.values())
+	s.mu.RUnlock()
+
+	dst = binary.AppendUvarint(dst, uint64(len(v)))
+	if len(v) == 0 {
+		return dst
+	}
+
+	if signed[V]() {
+		dst = binary.AppendVarint(dst, int64(v[0]))
+	} else {
+		dst = binary.AppendUvarint(dst, uint64(v[0]))
+	}
+
+	// The differences are computed modulo 2^64, which gives the actual
+	// differences of sorted signed integers as well.
+	for i := 1; i < len(v); i++ {
+		dst = binary.AppendUvarint(dst, uint64(v[i])-uint64(v[i-1]))
+	}
+
+	return dst
+}
+
+// DecodeDeltas inserts into `s` the integers encoded by AppendDeltas at the
+// start of `b`, and returns the number of bytes read.
+func DecodeDeltas[V Integer](b []byte, s *Set[V]) (int, error) {
+	n, read := binary.Uvarint(b)
+	if read <= 0 {
+		return 0, errCorruptDeltas
+	}
+
+	// Each integer takes at least a byte, which bounds the allocation.
+	if n > uint64(len(b)-read) {
+		return 0, errCorruptDeltas
+	}
+	if n == 0 {
+		return read, nil
+	}
+
+	v := make([]V, 0, n)
+
+	var (
+		u uint64
+		w int
+	)
+	if signed[V]() {
+		var x int64
+		x, w = binary.Varint(b[read:])
+		u = uint64(x)
+	} else {
+		u, w = binary.Uvarint(b[read:])
+	}
+	if w <= 0 || uint64(V(u)) != u {
+		return 0, errCorruptDeltas
+	}
+	read += w
+	v = append(v, V(u))
+
+	for i := uint64(1); i < n; i++ {
+		d, w := binary.Uvarint(b[read:])
+		if w <= 0 || d == 0 {
+			return 0, errCorruptDeltas
+		}
+		read += w
+
+		// Integers must be increasing and fit in V.
+		u += d
+		if uint64(V(u)) != u || V(u) <= v[len(v)-1] {
+			return 0, errCorruptDeltas
+		}
+		v = append(v, V(u))
+	}
+
+	s.Insert(v...)
+
+	return read, nil
+}
+
+// signed returns true iff `V` is a signed integer type.
+func signed[V Integer]() bool {
+	var zero V
+	return zero-1 < zero
+}
@@ -0,0 +1,143 @@
+package set_test
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestAppendDeltas(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.Set[int]
+	}{
+		{
+			name: "empty",
+			s:    set.New[int](),
+		},
+		{
+			name: "single value",
+			s:    set.New(-7),
+		},
+		{
+			name: "negative and positive",
+			s:    set.New(5, -3, 0, 1000000, math.MinInt, math.MaxInt),
+		},
+		{
+			name: "large",
+			s:    rangeSet(-1000, 1000),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			b := set.AppendDeltas([]byte("prefix"), tt.s)
+			b = append(b, "suffix"...)
+
+			got := set.New[int]()
+			n, err := set.DecodeDeltas(b[len("prefix"):], got)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.s, got, cmp.Comparer(equal(t))); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff("suffix", string(b[len("prefix")+n:])); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAppendDeltasUnsigned(t *testing.T) {
+	t.Parallel()
+
+	s := set.New[uint64](0, 1, math.MaxUint64, math.MaxUint64-1)
+
+	got := set.New[uint64]()
+	if _, err := set.DecodeDeltas(set.AppendDeltas(nil, s), got); err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(s) {
+		t.Errorf("got %v, want %v", got, s)
+	}
+}
+
+func TestAppendDeltasSize(t *testing.T) {
+	t.Parallel()
+
+	// Close IDs take a byte each once past the first one.
+	s := set.New[uint64]()
+	for i := uint64(0); i < 1000; i++ {
+		s.Insert(1<<40 + 3*i)
+	}
+
+	if got := len(set.AppendDeltas(nil, s)); got > 1010 {
+		t.Errorf("got %d bytes for 1000 close IDs", got)
+	}
+}
+
+func TestDecodeDeltasError(t *testing.T) {
+	t.Parallel()
+
+	uvarints := func(v ...uint64) []byte {
+		var b []byte
+		for _, x := range v {
+			b = binary.AppendUvarint(b, x)
+		}
+		return b
+	}
+
+	tests := []struct {
+		name string
+		b    []byte
+	}{
+		{
+			name: "empty",
+			b:    nil,
+		},
+		{
+			name: "truncated",
+			b:    uvarints(3, 2, 1),
+		},
+		{
+			name: "count past the input",
+			b:    uvarints(1<<62, 1),
+		},
+		{
+			name: "duplicate",
+			b:    uvarints(2, 2, 0),
+		},
+		{
+			name: "out of range",
+			b:    uvarints(2, 2*100, 100),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := set.New[int8]()
+			if _, err := set.DecodeDeltas(tt.b, s); err == nil {
+				t.Errorf("got no error, and values %v", s)
+			}
+			if diff := cmp.Diff(0, s.Len()); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
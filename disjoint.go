@@ -0,0 +1,101 @@
+package set
+
+// DisjointSet is a union-find structure partitioning comparables into disjoint
+// sets.
+//
+// Find and Union run in nearly constant amortized time using path compression
+// and union by rank. Since Find compresses paths, even concurrent calls to Find
+// must be synchronized: unlike Set, a DisjointSet is not safe for concurrent
+// use.
+type DisjointSet[V comparable] struct {
+	parent map[V]V
+	rank   map[V]int
+}
+
+// NewDisjointSet returns a DisjointSet where each of the given values is in a
+// set of its own.
+func NewDisjointSet[V comparable](v ...V) *DisjointSet[V] {
+	d := &DisjointSet[V]{
+		parent: make(map[V]V),
+		rank:   make(map[V]int),
+	}
+
+	for _, x := range v {
+		d.add(x)
+	}
+
+	return d
+}
+
+// Find returns the representative of the set containing `v`. Two values are in
+// the same set iff they have the same representative.
+//
+// A value not yet in `d` is added in a set of its own.
+func (d *DisjointSet[V]) Find(v V) V {
+	d.add(v)
+
+	root := v
+	for d.parent[root] != root {
+		root = d.parent[root]
+	}
+
+	for v != root {
+		v, d.parent[v] = d.parent[v], root
+	}
+
+	return root
+}
+
+// Union merges the sets containing `a` and `b`.
+func (d *DisjointSet[V]) Union(a, b V) {
+	ra, rb := d.Find(a), d.Find(b)
+	if ra == rb {
+		return
+	}
+
+	switch {
+	case d.rank[ra] < d.rank[rb]:
+		d.parent[ra] = rb
+	case d.rank[ra] > d.rank[rb]:
+		d.parent[rb] = ra
+	default:
+		d.parent[rb] = ra
+		d.rank[ra]++
+	}
+}
+
+// Connected returns true iff `a` and `b` are in the same set.
+func (d *DisjointSet[V]) Connected(a, b V) bool {
+	return d.Find(a) == d.Find(b)
+}
+
+// Len returns the number of values in `d`.
+func (d *DisjointSet[V]) Len() int {
+	return len(d.parent)
+}
+
+// Sets returns the disjoint sets of `d`.
+func (d *DisjointSet[V]) Sets() []*Set[V] {
+	m := make(map[V]*Set[V])
+
+	for k := range d.parent {
+		r := d.Find(k)
+		if _, ok := m[r]; !ok {
+			m[r] = New[V]()
+		}
+		m[r].Insert(k)
+	}
+
+	sets := make([]*Set[V], 0, len(m))
+	for _, s := range m {
+		sets = append(sets, s)
+	}
+
+	return sets
+}
+
+func (d *DisjointSet[V]) add(v V) {
+	if _, ok := d.parent[v]; !ok {
+		d.parent[v] = v
+	}
+}
@@ -0,0 +1,100 @@
+package set_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestDisjointSetConnected(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		union [][2]int
+		a, b  int
+		want  bool
+	}{
+		{
+			name:  "connected transitively",
+			union: [][2]int{{1, 2}, {2, 3}},
+			a:     1,
+			b:     3,
+			want:  true,
+		},
+		{
+			name:  "not connected",
+			union: [][2]int{{1, 2}, {3, 4}},
+			a:     1,
+			b:     4,
+			want:  false,
+		},
+		{
+			name: "unknown values",
+			a:    1,
+			b:    2,
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := set.NewDisjointSet[int]()
+			for _, u := range tt.union {
+				d.Union(u[0], u[1])
+			}
+
+			if diff := cmp.Diff(tt.want, d.Connected(tt.a, tt.b)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDisjointSetSets(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		d     *set.DisjointSet[int]
+		union [][2]int
+		want  []*set.Set[int]
+	}{
+		{
+			name:  "sets",
+			d:     set.NewDisjointSet(1, 2, 3, 4, 5),
+			union: [][2]int{{1, 2}, {3, 4}, {4, 1}},
+			want:  []*set.Set[int]{set.New(1, 2, 3, 4), set.New(5)},
+		},
+		{
+			name: "empty",
+			d:    set.NewDisjointSet[int](),
+			want: []*set.Set[int]{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			for _, u := range tt.union {
+				tt.d.Union(u[0], u[1])
+			}
+
+			if diff := cmp.Diff(tt.want, tt.d.Sets(), cmp.Comparer(equal(t)), cmpopts.SortSlices(func(s1, s2 *set.Set[int]) bool {
+				return s1.Len() > s2.Len()
+			})); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
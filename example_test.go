@@ -45,3 +45,34 @@ func ExampleSet_Intersection() {
 	// Output:
 	// [2]
 }
+
+func ExampleSet_Iter() {
+	s := set.New(1, 2, 3)
+
+	it := s.Iter()
+	defer it.Close()
+
+	var sum int
+	for v, ok := it.Next(); ok; v, ok = it.Next() {
+		sum += v
+	}
+
+	fmt.Println(sum)
+	// Output:
+	// 6
+}
+
+func ExampleSet_zeroValue() {
+	var user struct {
+		Name string
+		Tags set.Set[string]
+	}
+
+	user.Tags.Insert("admin")
+
+	fmt.Println(user.Tags.Contains("admin"))
+	fmt.Println(user.Tags.Len())
+	// Output:
+	// true
+	// 1
+}
@@ -0,0 +1,297 @@
+package set
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Expr is an expression of set algebra over named sets, which can be composed
+// and then evaluated in a single pass without building the intermediate sets.
+//
+// For example, to evaluate (A ∪ B) ∩ ¬C:
+//
+//	e := set.Ref[string]("A").Union(set.Ref[string]("B")).Difference(set.Ref[string]("C"))
+//	s, err := e.Eval(map[string]*set.Set[string]{"A": a, "B": b, "C": c})
+//
+// The zero value is not a valid expression.
+type Expr[V comparable] struct {
+	op   exprOp
+	name string
+	set  *Set[V]
+	args []Expr[V]
+}
+
+type exprOp int
+
+const (
+	exprRef exprOp = iota + 1
+	exprSet
+	exprUnion
+	exprIntersection
+	exprComplement
+)
+
+// Ref returns an expression of the set named `name` when the expression is
+// evaluated.
+func Ref[V comparable](name string) Expr[V] {
+	return Expr[V]{op: exprRef, name: name}
+}
+
+// Lit returns an expression of `s` itself.
+func Lit[V comparable](s *Set[V]) Expr[V] {
+	return Expr[V]{op: exprSet, set: s}
+}
+
+// Union returns an expression of the values in `e` or in any of `others`.
+func (e Expr[V]) Union(others ...Expr[V]) Expr[V] {
+	return e.join(exprUnion, others)
+}
+
+// Intersection returns an expression of the values in `e` and in all of
+// `others`.
+func (e Expr[V]) Intersection(others ...Expr[V]) Expr[V] {
+	return e.join(exprIntersection, others)
+}
+
+// Difference returns an expression of the values in `e` and not in `other`,
+// which is the intersection of `e` with the complement of `other`.
+func (e Expr[V]) Difference(other Expr[V]) Expr[V] {
+	return e.Intersection(other.Complement())
+}
+
+// Complement returns an expression of the values not in `e`.
+//
+// Since there is no set of all values, the values of a complement cannot be
+// enumerated: it may only be evaluated within an intersection with another
+// expression, or within another complement.
+func (e Expr[V]) Complement() Expr[V] {
+	if e.op == exprComplement {
+		return e.args[0]
+	}
+
+	return Expr[V]{op: exprComplement, args: []Expr[V]{e}}
+}
+
+// String returns `e` in the usual notation, for example "(A ∪ B) ∩ ¬C".
+func (e Expr[V]) String() string {
+	switch e.op {
+	case exprRef:
+		return e.name
+	case exprSet:
+		return e.set.String()
+	case exprComplement:
+		return "¬" + e.args[0].operand()
+	case exprUnion, exprIntersection:
+		sep := " ∪ "
+		if e.op == exprIntersection {
+			sep = " ∩ "
+		}
+
+		args := make([]string, len(e.args))
+		for i, a := range e.args {
+			args[i] = a.operand()
+		}
+		return strings.Join(args, sep)
+	default:
+		return "<invalid>"
+	}
+}
+
+// Eval returns a new Set of the values of `e`, where the sets referenced by Ref
+// are looked up in `sets`. It returns an error if a set is missing from `sets`
+// or if the values of a complement would have to be enumerated.
+//
+// All the sets are read under a single lock. Intersections are evaluated by
+// walking their smallest operand and returning early when it is empty, and
+// values are checked against the smallest sets first.
+func (e Expr[V]) Eval(sets map[string]*Set[V]) (*Set[V], error) {
+	var locked []*Set[V]
+
+	n, err := e.compile(sets, &locked)
+	if err != nil {
+		return nil, err
+	}
+	if !n.enumerable {
+		return nil, fmt.Errorf("set: cannot enumerate the values of %v", e)
+	}
+
+	defer rlockAll(locked...)()
+
+	n.estimate()
+
+	u := New[V]()
+	n.each(func(v V) { u.insert(v) })
+
+	return u, nil
+}
+
+// operand returns `e` formatted as the operand of another operation.
+func (e Expr[V]) operand() string {
+	if e.op == exprUnion || e.op == exprIntersection {
+		return "(" + e.String() + ")"
+	}
+
+	return e.String()
+}
+
+// join returns the expression applying `op` to `e` and `others`, flattening
+// operands applying the same operation.
+func (e Expr[V]) join(op exprOp, others []Expr[V]) Expr[V] {
+	j := Expr[V]{op: op}
+
+	for _, a := range append([]Expr[V]{e}, others...) {
+		if a.op == op {
+			j.args = append(j.args, a.args...)
+		} else {
+			j.args = append(j.args, a)
+		}
+	}
+
+	return j
+}
+
+// compile returns the tree evaluating `e`, with the sets resolved from `sets`
+// and appended to `locked`.
+func (e Expr[V]) compile(sets map[string]*Set[V], locked *[]*Set[V]) (*exprNode[V], error) {
+	n := &exprNode[V]{op: e.op}
+
+	switch e.op {
+	case exprRef, exprSet:
+		n.set = e.set
+		if e.op == exprRef {
+			var ok bool
+			if n.set, ok = sets[e.name]; !ok {
+				return nil, fmt.Errorf("set: no set named %q", e.name)
+			}
+		}
+		if n.set == nil {
+			return nil, fmt.Errorf("set: nil set in %v", e)
+		}
+
+		*locked = append(*locked, n.set)
+		n.enumerable = true
+
+		return n, nil
+	case exprUnion, exprIntersection, exprComplement:
+		n.enumerable = e.op == exprUnion
+
+		for _, a := range e.args {
+			m, err := a.compile(sets, locked)
+			if err != nil {
+				return nil, err
+			}
+			n.args = append(n.args, m)
+
+			switch e.op {
+			case exprUnion:
+				n.enumerable = n.enumerable && m.enumerable
+			case exprIntersection:
+				n.enumerable = n.enumerable || m.enumerable
+			}
+		}
+
+		return n, nil
+	default:
+		return nil, errors.New("set: invalid expression")
+	}
+}
+
+// exprNode is a compiled Expr, evaluated with its sets locked.
+type exprNode[V comparable] struct {
+	op   exprOp
+	set  *Set[V]
+	args []*exprNode[V]
+
+	// enumerable reports whether the values of the node can be walked,
+	// which is not the case of complements.
+	enumerable bool
+
+	// size is an upper bound of the number of values of an enumerable node.
+	size int
+}
+
+// estimate computes the size of `n` and its operands, and sorts the operands
+// of intersections by increasing size with complements last.
+func (n *exprNode[V]) estimate() {
+	for _, a := range n.args {
+		a.estimate()
+	}
+
+	switch n.op {
+	case exprRef, exprSet:
+		n.size = n.set.size()
+	case exprUnion:
+		for _, a := range n.args {
+			n.size += a.size
+		}
+	case exprIntersection:
+		slices.SortStableFunc(n.args, func(a, b *exprNode[V]) int {
+			if a.enumerable != b.enumerable {
+				if a.enumerable {
+					return -1
+				}
+				return 1
+			}
+			return cmp.Compare(a.size, b.size)
+		})
+		n.size = n.args[0].size
+	}
+}
+
+// contains returns true iff `v` is a value of `n`.
+func (n *exprNode[V]) contains(v V) bool {
+	switch n.op {
+	case exprRef, exprSet:
+		return n.set.contains(v)
+	case exprUnion:
+		for _, a := range n.args {
+			if a.contains(v) {
+				return true
+			}
+		}
+		return false
+	case exprIntersection:
+		for _, a := range n.args {
+			if !a.contains(v) {
+				return false
+			}
+		}
+		return true
+	default:
+		return !n.args[0].contains(v)
+	}
+}
+
+// each calls `f` with the values of `n`, which must be enumerable, possibly
+// more than once for the values of a union.
+func (n *exprNode[V]) each(f func(V)) {
+	switch n.op {
+	case exprRef, exprSet:
+		for k := range n.set.values() {
+			f(k)
+		}
+	case exprUnion:
+		for _, a := range n.args {
+			a.each(f)
+		}
+	case exprIntersection:
+		// The smallest enumerable operand is walked and the others are
+		// looked up.
+		walk, others := n.args[0], n.args[1:]
+		if walk.size == 0 {
+			return
+		}
+
+		walk.each(func(v V) {
+			for _, a := range others {
+				if !a.contains(v) {
+					return
+				}
+			}
+			f(v)
+		})
+	}
+}
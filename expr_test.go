@@ -0,0 +1,158 @@
+package set_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestExprEval(t *testing.T) {
+	t.Parallel()
+
+	a, b, c := set.Ref[int]("A"), set.Ref[int]("B"), set.Ref[int]("C")
+
+	sets := map[string]*set.Set[int]{
+		"A":     set.New(1, 2, 3, 4),
+		"B":     set.New(3, 4, 5, 6),
+		"C":     set.New(2, 4, 6),
+		"empty": set.New[int](),
+	}
+
+	tests := []struct {
+		name       string
+		e          set.Expr[int]
+		wantString string
+		want       *set.Set[int]
+	}{
+		{
+			name:       "ref",
+			e:          a,
+			wantString: "A",
+			want:       set.New(1, 2, 3, 4),
+		},
+		{
+			name:       "union",
+			e:          a.Union(b, c),
+			wantString: "A ∪ B ∪ C",
+			want:       set.New(1, 2, 3, 4, 5, 6),
+		},
+		{
+			name:       "intersection",
+			e:          a.Intersection(b),
+			wantString: "A ∩ B",
+			want:       set.New(3, 4),
+		},
+		{
+			name:       "union intersected with a complement",
+			e:          a.Union(b).Intersection(c.Complement()),
+			wantString: "(A ∪ B) ∩ ¬C",
+			want:       set.New(1, 3, 5),
+		},
+		{
+			name:       "difference",
+			e:          a.Difference(b.Intersection(c)),
+			wantString: "A ∩ ¬(B ∩ C)",
+			want:       set.New(1, 2, 3),
+		},
+		{
+			name:       "complement of a union",
+			e:          a.Intersection(b.Union(c.Complement()).Complement()),
+			wantString: "A ∩ ¬(B ∪ ¬C)",
+			want:       set.New(2),
+		},
+		{
+			name:       "double complement",
+			e:          a.Intersection(b.Complement().Complement()),
+			wantString: "A ∩ B",
+			want:       set.New(3, 4),
+		},
+		{
+			name:       "empty operand",
+			e:          a.Union(b).Intersection(set.Ref[int]("empty")),
+			wantString: "(A ∪ B) ∩ empty",
+			want:       set.New[int](),
+		},
+		{
+			name:       "literal",
+			e:          a.Intersection(set.Lit(set.New(4))),
+			wantString: "A ∩ [4]",
+			want:       set.New(4),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.wantString, tt.e.String()); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+
+			got, err := tt.e.Eval(sets)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got, cmp.Comparer(equal(t))); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExprEvalError(t *testing.T) {
+	t.Parallel()
+
+	a, b := set.Ref[int]("A"), set.Ref[int]("B")
+
+	sets := map[string]*set.Set[int]{
+		"A":   set.New(1, 2),
+		"B":   set.New(2, 3),
+		"nil": nil,
+	}
+
+	tests := []struct {
+		name string
+		e    set.Expr[int]
+	}{
+		{
+			name: "missing set",
+			e:    a.Union(set.Ref[int]("missing")),
+		},
+		{
+			name: "nil set",
+			e:    set.Ref[int]("nil"),
+		},
+		{
+			name: "complement",
+			e:    a.Complement(),
+		},
+		{
+			name: "complement in a union",
+			e:    a.Union(b.Complement()),
+		},
+		{
+			name: "intersection of complements",
+			e:    a.Complement().Intersection(b.Complement()),
+		},
+		{
+			name: "zero value",
+			e:    set.Expr[int]{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := tt.e.Eval(sets); err == nil {
+				t.Errorf("got no error evaluating %v", tt.e)
+			}
+		})
+	}
+}
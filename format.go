@@ -0,0 +1,104 @@
+package set
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// FormatOptions configures how FormatString formats a Set.
+type FormatOptions[V comparable] struct {
+	// Sorted sorts the values. Integers, floats and strings are sorted by
+	// value; other types are sorted by their Go-syntax representation, and
+	// values of different kinds, as in a Set[any], by kind first.
+	Sorted bool
+
+	// Open and Close are written before and after the values.
+	Open, Close string
+
+	// Separator is written between two values. It defaults to a space.
+	Separator string
+
+	// Format returns the text of a value. It defaults to fmt.Sprint.
+	Format func(V) string
+
+	// Limit is the maximum number of values written, after which the number
+	// of remaining values is written instead. Zero means no limit.
+	Limit int
+}
+
+// FormatString returns the values of `s` formatted as configured by `opts`.
+//
+// For example:
+//
+//	s = {3, 1, 4, 2}
+//	s.FormatString(set.FormatOptions[int]{Sorted: true, Open: "{", Close: "}", Separator: ", "}) = "{1, 2, 3, 4}"
+//	s.FormatString(set.FormatOptions[int]{Sorted: true, Limit: 2}) = "1 2 ... (2 more)"
+//
+// `s` is not locked while the values are formatted, so `opts.Format` may call
+// the methods of `s`.
+func (s *Set[V]) FormatString(opts FormatOptions[V]) string {
+	v, n := s.formatValues(opts.Sorted, opts.Limit)
+
+	sep := opts.Separator
+	if sep == "" {
+		sep = " "
+	}
+
+	format := opts.Format
+	if format == nil {
+		format = func(v V) string { return fmt.Sprint(v) }
+	}
+
+	var b strings.Builder
+	b.WriteString(opts.Open)
+
+	for i, k := range v {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(format(k))
+	}
+
+	if n > len(v) {
+		if len(v) > 0 {
+			b.WriteString(sep)
+		}
+		fmt.Fprintf(&b, "... (%d more)", n-len(v))
+	}
+
+	b.WriteString(opts.Close)
+
+	return b.String()
+}
+
+// formatValues returns the values of `s` to format, sorted if `sorted`, and
+// truncated to `limit` values unless it is zero, along with the size of `s`.
+func (s *Set[V]) formatValues(sorted bool, limit int) ([]V, int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	n := s.size()
+
+	if sorted {
+		v := slices.SortedFunc(s.values(), compareValues[V])
+		if limit > 0 && limit < n {
+			v = v[:limit]
+		}
+		return v, n
+	}
+
+	if limit <= 0 || limit > n {
+		limit = n
+	}
+
+	v := make([]V, 0, limit)
+	for k := range ordered(s.values()) {
+		if len(v) == limit {
+			break
+		}
+		v = append(v, k)
+	}
+
+	return v, n
+}
@@ -0,0 +1,91 @@
+package set_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestSetFormatString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.Set[int]
+		opts set.FormatOptions[int]
+		want string
+	}{
+		{
+			name: "empty",
+			s:    set.New[int](),
+			opts: set.FormatOptions[int]{Open: "[", Close: "]"},
+			want: "[]",
+		},
+		{
+			name: "sorted",
+			s:    set.New(10, 9, -1, 2),
+			opts: set.FormatOptions[int]{Sorted: true},
+			want: "-1 2 9 10",
+		},
+		{
+			name: "brackets and separator",
+			s:    set.New(3, 1, 2),
+			opts: set.FormatOptions[int]{Sorted: true, Open: "{", Close: "}", Separator: ", "},
+			want: "{1, 2, 3}",
+		},
+		{
+			name: "format",
+			s:    set.New(255, 16),
+			opts: set.FormatOptions[int]{Sorted: true, Format: func(v int) string { return "0x" + strconv.FormatInt(int64(v), 16) }},
+			want: "0x10 0xff",
+		},
+		{
+			name: "limit",
+			s:    rangeSet(0, 100),
+			opts: set.FormatOptions[int]{Sorted: true, Open: "[", Close: "]", Limit: 3},
+			want: "[0 1 2 ... (97 more)]",
+		},
+		{
+			name: "limit above size",
+			s:    set.New(2, 1),
+			opts: set.FormatOptions[int]{Sorted: true, Limit: 3},
+			want: "1 2",
+		},
+		{
+			name: "unsorted limit",
+			s:    set.New(7),
+			opts: set.FormatOptions[int]{Limit: 1},
+			want: "7",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, tt.s.FormatString(tt.opts)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetFormatStringUnsorted(t *testing.T) {
+	t.Parallel()
+
+	// Any 10 of the values are written, followed by the count of the others.
+	got := rangeSet(0, 100).FormatString(set.FormatOptions[int]{Separator: ",", Limit: 10})
+
+	if diff := cmp.Diff(10, strings.Count(got, ",")); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+	if !strings.HasSuffix(got, ",... (90 more)") {
+		t.Errorf("got %q without the count of the other values", got)
+	}
+}
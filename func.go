@@ -0,0 +1,96 @@
+package set
+
+// EqualFunc returns true iff every value of `s` is equivalent to a value of
+// `t` and every value of `t` is equivalent to a value of `s`, using `eq` to
+// compare values rather than ==. `eq` should be an equivalence relation.
+//
+// EqualFunc compares every value of `s` with the values of `t`, so it takes
+// time proportional to the product of their sizes. `eq` must not modify `s` or
+// `t`.
+//
+// For example, with `eq` comparing floats to within 0.01:
+//
+//	s = {1.0, 2.0}
+//	t = {1.001, 2.0, 2.002}
+//	set.EqualFunc(s, t, eq) = true
+func EqualFunc[V comparable](s, t *Set[V], eq func(a, b V) bool) bool {
+	defer rlockAll(s, t)()
+
+	return coveredFunc(s, t, eq) && coveredFunc(t, s, eq)
+}
+
+// DifferenceFunc returns a new Set whose values are the values of `s` that
+// are not equivalent to any value of `t`, using `eq` to compare values. See
+// EqualFunc for the requirements on `eq`.
+//
+// For example, with `eq` comparing floats to within 0.01:
+//
+//	s = {1.0, 2.0, 3.0}
+//	t = {1.001, 2.5}
+//	set.DifferenceFunc(s, t, eq) = {2.0, 3.0}
+func DifferenceFunc[V comparable](s, t *Set[V], eq func(a, b V) bool) *Set[V] {
+	defer rlockAll(s, t)()
+
+	u := New[V]()
+
+	for k := range s.values() {
+		if !containsFunc(t, k, eq) {
+			u.insert(k)
+		}
+	}
+
+	return u
+}
+
+// IntersectionFunc returns a new Set whose values are the values of `s` that
+// are equivalent to a value of `t`, using `eq` to compare values. See
+// EqualFunc for the requirements on `eq`.
+//
+// The values are those of `s`, so IntersectionFunc is not commutative.
+//
+// For example, with `eq` comparing floats to within 0.01:
+//
+//	s = {1.0, 2.0, 3.0}
+//	t = {1.001, 2.5}
+//	set.IntersectionFunc(s, t, eq) = {1.0}
+//	set.IntersectionFunc(t, s, eq) = {1.001}
+func IntersectionFunc[V comparable](s, t *Set[V], eq func(a, b V) bool) *Set[V] {
+	defer rlockAll(s, t)()
+
+	u := New[V]()
+
+	for k := range s.values() {
+		if containsFunc(t, k, eq) {
+			u.insert(k)
+		}
+	}
+
+	return u
+}
+
+// coveredFunc returns true iff every value of `s` is equivalent to a value of
+// `t`.
+func coveredFunc[V comparable](s, t *Set[V], eq func(a, b V) bool) bool {
+	for k := range s.values() {
+		if !containsFunc(t, k, eq) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// containsFunc returns true iff `s` has a value equivalent to `v`.
+func containsFunc[V comparable](s *Set[V], v V, eq func(a, b V) bool) bool {
+	if s.has(v) {
+		return true
+	}
+
+	for k := range s.values() {
+		if eq(v, k) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,158 @@
+package set_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+// near returns true iff `a` and `b` are within 0.01 of each other.
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 0.01
+}
+
+func floatsEqual(s, t *set.Set[float64]) bool {
+	return s.Equal(t)
+}
+
+func TestEqualFunc(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		ID   int
+		Seen int64
+	}
+
+	sameID := func(a, b user) bool { return a.ID == b.ID }
+
+	tests := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{
+			name: "both empty",
+			got:  set.EqualFunc(set.New[float64](), set.New[float64](), near),
+			want: true,
+		},
+		{
+			name: "within tolerance",
+			got:  set.EqualFunc(set.New(1.0, 2.0), set.New(1.001, 2.0, 2.002), near),
+			want: true,
+		},
+		{
+			name: "missing from t",
+			got:  set.EqualFunc(set.New(1.0, 2.0, 3.0), set.New(1.001, 2.0), near),
+			want: false,
+		},
+		{
+			name: "missing from s",
+			got:  set.EqualFunc(set.New(1.0), set.New(1.001, 2.0), near),
+			want: false,
+		},
+		{
+			name: "ignored field",
+			got:  set.EqualFunc(set.New(user{1, 10}, user{2, 20}), set.New(user{2, 21}, user{1, 11}), sameID),
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, tt.got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDifferenceFunc(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.Set[float64]
+		t    *set.Set[float64]
+		want *set.Set[float64]
+	}{
+		{
+			name: "empty t",
+			s:    set.New(1.0, 2.0),
+			t:    set.New[float64](),
+			want: set.New(1.0, 2.0),
+		},
+		{
+			name: "within tolerance",
+			s:    set.New(1.0, 2.0, 3.0),
+			t:    set.New(1.001, 2.5),
+			want: set.New(2.0, 3.0),
+		},
+		{
+			name: "all equivalent",
+			s:    set.New(1.0, 1.005),
+			t:    set.New(1.001),
+			want: set.New[float64](),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, set.DifferenceFunc(tt.s, tt.t, near), cmp.Comparer(floatsEqual)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIntersectionFunc(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.Set[float64]
+		t    *set.Set[float64]
+		want *set.Set[float64]
+	}{
+		{
+			name: "empty t",
+			s:    set.New(1.0, 2.0),
+			t:    set.New[float64](),
+			want: set.New[float64](),
+		},
+		{
+			name: "values of s",
+			s:    set.New(1.0, 2.0, 3.0),
+			t:    set.New(1.001, 2.5),
+			want: set.New(1.0),
+		},
+		{
+			name: "values of t",
+			s:    set.New(1.001, 2.5),
+			t:    set.New(1.0, 2.0, 3.0),
+			want: set.New(1.001),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, set.IntersectionFunc(tt.s, tt.t, near), cmp.Comparer(floatsEqual)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
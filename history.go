@@ -0,0 +1,163 @@
+package set
+
+import (
+	"slices"
+)
+
+// history records the recent changes of a Set for Undo and Redo.
+type history[V comparable] struct {
+	limit int
+
+	undo []Patch[V]
+	redo []Patch[V]
+
+	// step accumulates the changes of the call in progress, and depth counts
+	// the nested calls making it up.
+	step  Patch[V]
+	depth int
+
+	// replaying is set while Undo or Redo apply a step, which must not be
+	// recorded as a new one.
+	replaying bool
+}
+
+// WithHistory makes a Set record its last `n` changes so that they can be
+// reverted with Undo and reapplied with Redo.
+//
+// Each call to a method modifying the Set, such as Insert with several values,
+// is recorded as a single change. With `n` of zero no change is kept, so Undo
+// always returns false.
+//
+// WithHistory panics if `n` is negative.
+func WithHistory[V comparable](n int) Option[V] {
+	if n < 0 {
+		panic("set: history length must not be negative")
+	}
+
+	return func(c *config[V]) {
+		c.history = &history[V]{limit: n}
+	}
+}
+
+// Inverse returns the Patch reverting `p`, given that its sets are disjoint.
+func (p Patch[V]) Inverse() Patch[V] {
+	return Patch[V]{Added: p.Removed, Removed: p.Added}
+}
+
+// Undo reverts the last recorded change of `s` and reports whether there was
+// one. See WithHistory.
+func (s *Set[V]) Undo() bool {
+	defer s.unlock(s.lock("Undo"))
+
+	h := s.history()
+	if h == nil || len(h.undo) == 0 {
+		return false
+	}
+
+	p := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+	h.redo = append(h.redo, p)
+
+	s.replay(p.Inverse())
+
+	return true
+}
+
+// Redo reapplies the last change reverted by Undo and reports whether there
+// was one. Changes made since the last Undo discard the changes to redo.
+func (s *Set[V]) Redo() bool {
+	defer s.unlock(s.lock("Redo"))
+
+	h := s.history()
+	if h == nil || len(h.redo) == 0 {
+		return false
+	}
+
+	p := h.redo[len(h.redo)-1]
+	h.redo = h.redo[:len(h.redo)-1]
+	h.undo = append(h.undo, p)
+
+	s.replay(p)
+
+	return true
+}
+
+// replay applies `p` to `s` without recording it.
+func (s *Set[V]) replay(p Patch[V]) {
+	h := s.history()
+
+	h.replaying = true
+	defer func() { h.replaying = false }()
+
+	s.applyPatch(p.Removed.values(), p.Added.values())
+}
+
+// history returns the history of `s`, or nil if it records none.
+func (s *Set[V]) history() *history[V] {
+	if s.cfg == nil {
+		return nil
+	}
+
+	return s.cfg.history
+}
+
+// beginStep marks the start of a call modifying `s`, whose changes are
+// recorded as a single step until the matching endStep.
+func (s *Set[V]) beginStep() {
+	if h := s.history(); h != nil {
+		h.depth++
+	}
+}
+
+// endStep marks the end of a call started by beginStep.
+func (s *Set[V]) endStep() {
+	h := s.history()
+	if h == nil {
+		return
+	}
+
+	if h.depth--; h.depth > 0 || h.step.Added == nil {
+		return
+	}
+
+	if h.step.Added.Len() > 0 || h.step.Removed.Len() > 0 {
+		h.undo = append(h.undo, h.step)
+		if len(h.undo) > h.limit {
+			h.undo = slices.Delete(h.undo, 0, len(h.undo)-h.limit)
+		}
+		h.redo = nil
+	}
+
+	h.step = Patch[V]{}
+}
+
+// recordInsert records the insertion of `v` into `s`.
+func (s *Set[V]) recordInsert(v V) {
+	if h := s.history(); h != nil && !h.replaying {
+		h.record(v, true)
+	}
+}
+
+// recordDelete records the deletion of `v` from `s`.
+func (s *Set[V]) recordDelete(v V) {
+	if h := s.history(); h != nil && !h.replaying {
+		h.record(v, false)
+	}
+}
+
+// record adds the insertion or deletion of `v` to the step in progress, so
+// that a value inserted and then deleted within a step leaves no change.
+func (h *history[V]) record(v V, inserted bool) {
+	if h.step.Added == nil {
+		h.step = Patch[V]{Added: New[V](), Removed: New[V]()}
+	}
+
+	added, removed := h.step.Added, h.step.Removed
+	if !inserted {
+		added, removed = removed, added
+	}
+
+	if !removed.delete(v) {
+		added.insert(v)
+	}
+}
@@ -0,0 +1,172 @@
+package set_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestSetUndo(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		limit int
+		f     func(*set.Set[int])
+		undos int
+		redos int
+		want  *set.Set[int]
+	}{
+		{
+			name:  "undo insert",
+			limit: 10,
+			f:     func(s *set.Set[int]) { s.Insert(1, 2) },
+			undos: 1,
+			want:  set.New[int](),
+		},
+		{
+			name:  "undo delete",
+			limit: 10,
+			f: func(s *set.Set[int]) {
+				s.Insert(1, 2)
+				s.Delete(1, 3)
+			},
+			undos: 1,
+			want:  set.New(1, 2),
+		},
+		{
+			name:  "undo reset",
+			limit: 10,
+			f: func(s *set.Set[int]) {
+				s.Insert(1, 2)
+				s.Reset()
+			},
+			undos: 1,
+			want:  set.New(1, 2),
+		},
+		{
+			name:  "unchanged calls are not recorded",
+			limit: 10,
+			f: func(s *set.Set[int]) {
+				s.Insert(1)
+				s.Insert(1)
+				s.Delete(2)
+			},
+			undos: 1,
+			want:  set.New[int](),
+		},
+		{
+			name:  "no history",
+			limit: 0,
+			f: func(s *set.Set[int]) {
+				s.Insert(1)
+				s.Insert(2)
+			},
+			undos: 1,
+			want:  set.New(1, 2),
+		},
+		{
+			name:  "undo beyond limit",
+			limit: 2,
+			f: func(s *set.Set[int]) {
+				s.Insert(1)
+				s.Insert(2)
+				s.Insert(3)
+			},
+			undos: 5,
+			want:  set.New(1),
+		},
+		{
+			name:  "redo",
+			limit: 10,
+			f: func(s *set.Set[int]) {
+				s.Insert(1)
+				s.Insert(2)
+				s.Insert(3)
+			},
+			undos: 3,
+			redos: 2,
+			want:  set.New(1, 2),
+		},
+		{
+			name:  "change discards redo",
+			limit: 10,
+			f: func(s *set.Set[int]) {
+				s.Insert(1)
+				s.Insert(2)
+				s.Undo()
+				s.Insert(3)
+			},
+			redos: 1,
+			want:  set.New(1, 3),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := set.NewWithOptions(set.WithHistory[int](tt.limit))
+
+			tt.f(s)
+			for i := 0; i < tt.undos; i++ {
+				s.Undo()
+			}
+			for i := 0; i < tt.redos; i++ {
+				s.Redo()
+			}
+
+			if diff := cmp.Diff(tt.want, s); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetUndoReadFrom(t *testing.T) {
+	t.Parallel()
+
+	s := set.NewWithOptions(set.WithHistory[string](10))
+	s.Insert("a")
+
+	if _, err := s.ReadFrom(strings.NewReader("b\nc\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(true, s.Undo()); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(set.New("a"), s); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
+
+func TestSetUndoWithoutHistory(t *testing.T) {
+	t.Parallel()
+
+	s := set.New(1)
+
+	if diff := cmp.Diff(false, s.Undo()); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(false, s.Redo()); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
+
+func TestWithHistoryPanic(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("got no panic with a negative history length")
+		}
+	}()
+
+	set.WithHistory[int](-1)
+}
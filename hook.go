@@ -0,0 +1,30 @@
+package set
+
+// OnInsert registers `f` to be called with every value inserted into `s`.
+//
+// Hooks are called synchronously, in registration order, after the value has
+// been inserted. Inserting a value already in `s` does not call them.
+//
+// Hooks are called with `s` locked, so they must not call the methods of `s`.
+func (s *Set[V]) OnInsert(f func(V)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.config()
+	c.onInsert = append(c.onInsert, f)
+}
+
+// OnDelete registers `f` to be called with every value deleted from `s`,
+// including by PopAny.
+//
+// Hooks are called synchronously, in registration order, after the value has
+// been deleted. Deleting a value missing from `s` does not call them.
+//
+// Hooks are called with `s` locked, so they must not call the methods of `s`.
+func (s *Set[V]) OnDelete(f func(V)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.config()
+	c.onDelete = append(c.onDelete, f)
+}
@@ -0,0 +1,91 @@
+package set_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestSetOnInsert(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.Set[int]
+		v    []int
+		want []int
+	}{
+		{
+			name: "insert",
+			s:    set.New(1),
+			v:    []int{2, 3},
+			want: []int{2, 3},
+		},
+		{
+			name: "insert existing",
+			s:    set.New(1),
+			v:    []int{1, 2, 2},
+			want: []int{2},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []int
+			tt.s.OnInsert(func(v int) { got = append(got, v) })
+
+			tt.s.Insert(tt.v...)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetOnDelete(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.Set[int]
+		f    func(*set.Set[int])
+		want []int
+	}{
+		{
+			name: "delete",
+			s:    set.New(1, 2, 3),
+			f:    func(s *set.Set[int]) { s.Delete(1, 4, 3) },
+			want: []int{1, 3},
+		},
+		{
+			name: "pop",
+			s:    set.New(1),
+			f:    func(s *set.Set[int]) { s.PopAny() },
+			want: []int{1},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []int
+			tt.s.OnDelete(func(v int) { got = append(got, v) })
+
+			tt.f(tt.s)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
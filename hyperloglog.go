@@ -0,0 +1,104 @@
+package set
+
+import (
+	"hash/maphash"
+	"math"
+	"math/bits"
+)
+
+const (
+	hllPrecision = 14
+	hllRegisters = 1 << hllPrecision
+)
+
+// hashSeed is shared by all sketches so that sketches of different sets can be
+// merged within a process.
+var hashSeed = maphash.MakeSeed()
+
+// HyperLogLog is a sketch estimating the number of distinct values added to it,
+// with a standard error of about 0.8% using 16 KiB of memory.
+//
+// The zero value is an empty sketch ready to use. Unlike Set, a HyperLogLog is
+// not safe for concurrent use.
+type HyperLogLog struct {
+	registers *[hllRegisters]uint8
+}
+
+// Sketch returns a HyperLogLog containing the values of `s`.
+//
+// Values are hashed with a seed chosen at process start, so sketches returned
+// by Sketch can be merged with each other but not with sketches built in
+// another process.
+func (s *Set[V]) Sketch() *HyperLogLog {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	h := &HyperLogLog{}
+
+	for k := range s.values() {
+		h.AddHash(maphash.Comparable(hashSeed, k))
+	}
+
+	return h
+}
+
+// AddHash adds a value to `h` by its 64-bit hash. The hash must be uniformly
+// distributed, and the same hash function must be used for all sketches that
+// are merged together.
+func (h *HyperLogLog) AddHash(x uint64) {
+	if h.registers == nil {
+		h.registers = new([hllRegisters]uint8)
+	}
+
+	i := x >> (64 - hllPrecision)
+	r := uint8(bits.LeadingZeros64(x<<hllPrecision|1<<(hllPrecision-1)) + 1)
+
+	if r > h.registers[i] {
+		h.registers[i] = r
+	}
+}
+
+// Estimate returns the estimated number of distinct values added to `h`.
+func (h *HyperLogLog) Estimate() uint64 {
+	if h.registers == nil {
+		return 0
+	}
+
+	var (
+		sum   float64
+		zeros int
+	)
+
+	for _, r := range h.registers {
+		sum += 1 / float64(uint64(1)<<r)
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	const m = float64(hllRegisters)
+
+	e := 0.7213 / (1 + 1.079/m) * m * m / sum
+	if e <= 2.5*m && zeros > 0 {
+		e = m * math.Log(m/float64(zeros))
+	}
+
+	return uint64(e + 0.5)
+}
+
+// Merge adds all the values of `t` to `h`, so that `h` estimates the
+// cardinality of the union of both.
+func (h *HyperLogLog) Merge(t *HyperLogLog) {
+	if t.registers == nil {
+		return
+	}
+	if h.registers == nil {
+		h.registers = new([hllRegisters]uint8)
+	}
+
+	for i, r := range t.registers {
+		if r > h.registers[i] {
+			h.registers[i] = r
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package set_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestHyperLogLogEstimate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.Set[int]
+		want int
+	}{
+		{
+			name: "empty",
+			s:    set.New[int](),
+			want: 0,
+		},
+		{
+			name: "small",
+			s:    set.New(1, 2, 3),
+			want: 3,
+		},
+		{
+			name: "large",
+			s:    rangeSet(0, 100000),
+			want: 100000,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := float64(tt.s.Sketch().Estimate())
+
+			if diff := cmp.Diff(float64(tt.want), got, cmp.Comparer(approx(0.03))); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHyperLogLogMerge(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.Set[int]
+		t    *set.Set[int]
+		want int
+	}{
+		{
+			name: "overlapping",
+			s:    rangeSet(0, 50000),
+			t:    rangeSet(25000, 75000),
+			want: 75000,
+		},
+		{
+			name: "merge empty",
+			s:    rangeSet(0, 1000),
+			t:    set.New[int](),
+			want: 1000,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := tt.s.Sketch()
+			h.Merge(tt.t.Sketch())
+
+			if diff := cmp.Diff(float64(tt.want), float64(h.Estimate()), cmp.Comparer(approx(0.03))); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
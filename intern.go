@@ -0,0 +1,105 @@
+package set
+
+import (
+	"strings"
+	"sync"
+)
+
+// Interner is a set of strings returning canonical instances, so that equal
+// strings kept by a program share their memory.
+//
+// Unlike unique.Make, an Interner keeps its strings until Reset and can list
+// them. The zero value is an empty Interner ready to use, and an Interner is
+// safe for concurrent use.
+type Interner struct {
+	mu sync.RWMutex
+	m  map[string]string
+}
+
+// Intern returns the string of `i` equal to `v`, inserting a copy of `v` if
+// there is none. The copy does not share the memory of `v`, so interning a
+// substring of a large buffer does not keep the buffer alive.
+func (i *Interner) Intern(v string) string {
+	i.mu.RLock()
+	s, ok := i.m[v]
+	i.mu.RUnlock()
+
+	if ok {
+		return s
+	}
+
+	return i.insert(v)
+}
+
+// InternBytes is like Intern for the string whose bytes are `b`, without
+// allocating if the string is already in `i`.
+func (i *Interner) InternBytes(b []byte) string {
+	i.mu.RLock()
+	s, ok := i.m[string(b)]
+	i.mu.RUnlock()
+
+	if ok {
+		return s
+	}
+
+	return i.insert(string(b))
+}
+
+// Contains returns true iff `i` contains `v`.
+func (i *Interner) Contains(v string) bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	_, ok := i.m[v]
+	return ok
+}
+
+// Len returns the number of strings in `i`.
+func (i *Interner) Len() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return len(i.m)
+}
+
+// ToSet returns a Set of the strings of `i`.
+func (i *Interner) ToSet() *Set[string] {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	s := newSized[string](len(i.m))
+	for v := range i.m {
+		s.insert(v)
+	}
+
+	return s
+}
+
+// Reset removes all the strings of `i`. The strings returned before remain
+// valid, but are no longer shared with the strings interned after.
+func (i *Interner) Reset() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.m = nil
+}
+
+// insert inserts a copy of `v` into `i` unless it was inserted concurrently,
+// and returns the canonical string.
+func (i *Interner) insert(v string) string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if s, ok := i.m[v]; ok {
+		return s
+	}
+
+	if i.m == nil {
+		i.m = make(map[string]string)
+	}
+
+	s := strings.Clone(v)
+	i.m[s] = s
+
+	return s
+}
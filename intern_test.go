@@ -0,0 +1,80 @@
+package set_test
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"unsafe"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestInterner(t *testing.T) {
+	t.Parallel()
+
+	var i set.Interner
+
+	buf := []byte("foo bar foo")
+
+	foo := i.Intern(string(buf[:3]))
+	if diff := cmp.Diff("foo", foo); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+	if unsafe.StringData(foo) == &buf[0] {
+		t.Error("got a string sharing the memory of the interned one")
+	}
+
+	for _, v := range []string{i.Intern(string(buf[8:])), i.InternBytes(buf[8:])} {
+		if unsafe.StringData(v) != unsafe.StringData(foo) {
+			t.Error("got a string not sharing the memory of the canonical one")
+		}
+	}
+
+	i.InternBytes(buf[4:7])
+
+	if diff := cmp.Diff(2, i.Len()); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+	if !i.Contains("bar") || i.Contains("baz") {
+		t.Error("got the wrong membership")
+	}
+	if !i.ToSet().Equal(set.New("foo", "bar")) {
+		t.Errorf("got strings %v", i.ToSet())
+	}
+
+	i.Reset()
+	if diff := cmp.Diff(0, i.Len()); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
+
+func TestInternerConcurrent(t *testing.T) {
+	t.Parallel()
+
+	var (
+		i       set.Interner
+		wg      sync.WaitGroup
+		results [4][]string
+	)
+
+	for w := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 100; n++ {
+				results[w] = append(results[w], i.Intern(strconv.Itoa(n)))
+			}
+		}()
+	}
+	wg.Wait()
+
+	for w := range results[1:] {
+		for n, v := range results[w+1] {
+			if unsafe.StringData(v) != unsafe.StringData(results[0][n]) {
+				t.Fatalf("got different instances of %q", v)
+			}
+		}
+	}
+}
@@ -0,0 +1,326 @@
+package set
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Interval is a half-open range [Lo, Hi) of ordered values.
+type Interval[V cmp.Ordered] struct {
+	Lo, Hi V
+}
+
+// IntervalSet is a set of ordered values stored as coalesced intervals.
+//
+// Intervals are half-open, so adjacent intervals such as [1, 3) and [3, 5) are
+// coalesced into [1, 5) whatever the value type is. The methods taking closed
+// intervals, such as InsertClosed, can also hold the greatest value of an
+// integer type, such as port 65535 in an IntervalSet[uint16], or +Inf.
+//
+// Unlike Set, an IntervalSet is not safe for concurrent use.
+type IntervalSet[V cmp.Ordered] struct {
+	// ivs is sorted, and its intervals are non-empty and neither overlap
+	// nor touch each other.
+	ivs []Interval[V]
+
+	// top is set if the greatest value of V, which no interval can hold, is
+	// in the set.
+	top bool
+}
+
+// NewIntervalSet returns an IntervalSet from the given intervals.
+func NewIntervalSet[V cmp.Ordered](iv ...Interval[V]) *IntervalSet[V] {
+	s := &IntervalSet[V]{}
+
+	for _, x := range iv {
+		s.Insert(x.Lo, x.Hi)
+	}
+
+	return s
+}
+
+// Clone returns a new IntervalSet that is a copy of `s`.
+func (s *IntervalSet[V]) Clone() *IntervalSet[V] {
+	return &IntervalSet[V]{ivs: slices.Clone(s.ivs), top: s.top}
+}
+
+// Insert adds the values in [lo, hi) to `s`.
+func (s *IntervalSet[V]) Insert(lo, hi V) {
+	if lo >= hi {
+		return
+	}
+
+	i := s.search(func(iv Interval[V]) bool { return iv.Hi >= lo })
+	j := s.search(func(iv Interval[V]) bool { return iv.Lo > hi })
+
+	if i < j {
+		lo = min(lo, s.ivs[i].Lo)
+		hi = max(hi, s.ivs[j-1].Hi)
+	}
+
+	s.ivs = slices.Replace(s.ivs, i, j, Interval[V]{lo, hi})
+}
+
+// InsertClosed adds the values in [lo, hi] to `s`.
+func (s *IntervalSet[V]) InsertClosed(lo, hi V) {
+	if lo > hi {
+		return
+	}
+
+	if next, ok := successor(hi); ok {
+		s.Insert(lo, next)
+		return
+	}
+
+	s.Insert(lo, hi)
+	s.top = true
+}
+
+// Delete removes the values in [lo, hi) from `s`.
+func (s *IntervalSet[V]) Delete(lo, hi V) {
+	if lo >= hi {
+		return
+	}
+
+	i := s.search(func(iv Interval[V]) bool { return iv.Hi > lo })
+	j := s.search(func(iv Interval[V]) bool { return iv.Lo >= hi })
+
+	if i == j {
+		return
+	}
+
+	var rest []Interval[V]
+	if first := s.ivs[i]; first.Lo < lo {
+		rest = append(rest, Interval[V]{first.Lo, lo})
+	}
+	if last := s.ivs[j-1]; last.Hi > hi {
+		rest = append(rest, Interval[V]{hi, last.Hi})
+	}
+
+	s.ivs = slices.Replace(s.ivs, i, j, rest...)
+}
+
+// DeleteClosed removes the values in [lo, hi] from `s`.
+func (s *IntervalSet[V]) DeleteClosed(lo, hi V) {
+	if lo > hi {
+		return
+	}
+
+	if next, ok := successor(hi); ok {
+		s.Delete(lo, next)
+		return
+	}
+
+	s.Delete(lo, hi)
+	s.top = false
+}
+
+// Has returns true iff `v` is in one of the intervals of `s`.
+func (s *IntervalSet[V]) Has(v V) bool {
+	if s.top {
+		if _, ok := successor(v); !ok {
+			return true
+		}
+	}
+
+	i := s.search(func(iv Interval[V]) bool { return iv.Hi > v })
+
+	return i < len(s.ivs) && s.ivs[i].Lo <= v
+}
+
+// Equal returns true iff `s` and `t` contain the same values.
+func (s *IntervalSet[V]) Equal(t *IntervalSet[V]) bool {
+	return s.top == t.top && slices.Equal(s.ivs, t.ivs)
+}
+
+// Intervals returns the coalesced intervals of `s` in ascending order. The
+// greatest value of V, which only the methods taking closed intervals can
+// insert, is not in any of them; Has reports whether `s` contains it.
+func (s *IntervalSet[V]) Intervals() []Interval[V] {
+	return slices.Clone(s.ivs)
+}
+
+// Len returns the number of coalesced intervals in `s`.
+func (s *IntervalSet[V]) Len() int {
+	return len(s.ivs)
+}
+
+// String implements fmt.Stringer.
+func (s *IntervalSet[V]) String() string {
+	// The greatest value of V closes the last interval if it ends there, and
+	// is written as an interval of its own otherwise.
+	closed := s.top && len(s.ivs) > 0 && isGreatest(s.ivs[len(s.ivs)-1].Hi)
+
+	var b strings.Builder
+
+	b.WriteByte('[')
+	for i, iv := range s.ivs {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		if closed && i == len(s.ivs)-1 {
+			fmt.Fprintf(&b, "[%v, %v]", iv.Lo, iv.Hi)
+		} else {
+			fmt.Fprintf(&b, "[%v, %v)", iv.Lo, iv.Hi)
+		}
+	}
+	if s.top && !closed {
+		if len(s.ivs) > 0 {
+			b.WriteByte(' ')
+		}
+		g, _ := greatest[V]()
+		fmt.Fprintf(&b, "[%v, %v]", g, g)
+	}
+	b.WriteByte(']')
+
+	return b.String()
+}
+
+// Union returns a new IntervalSet whose values are included in either `s` or
+// `t`.
+//
+// For example:
+//
+//	s = {[1, 3), [5, 7)}
+//	t = {[3, 4)}
+//	s.Union(t) = {[1, 4), [5, 7)}
+func (s *IntervalSet[V]) Union(t *IntervalSet[V]) *IntervalSet[V] {
+	u := s.Clone()
+	u.top = s.top || t.top
+
+	for _, iv := range t.ivs {
+		u.Insert(iv.Lo, iv.Hi)
+	}
+
+	return u
+}
+
+// Intersection returns a new IntervalSet whose values are included in both `s`
+// and `t`.
+//
+// For example:
+//
+//	s = {[1, 5)}
+//	t = {[0, 2), [4, 8)}
+//	s.Intersection(t) = {[1, 2), [4, 5)}
+func (s *IntervalSet[V]) Intersection(t *IntervalSet[V]) *IntervalSet[V] {
+	u := &IntervalSet[V]{top: s.top && t.top}
+
+	for i, j := 0, 0; i < len(s.ivs) && j < len(t.ivs); {
+		a, b := s.ivs[i], t.ivs[j]
+
+		if lo, hi := max(a.Lo, b.Lo), min(a.Hi, b.Hi); lo < hi {
+			u.ivs = append(u.ivs, Interval[V]{lo, hi})
+		}
+
+		if a.Hi < b.Hi {
+			i++
+		} else {
+			j++
+		}
+	}
+
+	return u
+}
+
+// Difference returns a new IntervalSet whose values are in `s` and not in `t`.
+//
+// For example:
+//
+//	s = {[1, 5)}
+//	t = {[2, 3)}
+//	s.Difference(t) = {[1, 2), [3, 5)}
+func (s *IntervalSet[V]) Difference(t *IntervalSet[V]) *IntervalSet[V] {
+	u := s.Clone()
+	u.top = s.top && !t.top
+
+	for _, iv := range t.ivs {
+		u.Delete(iv.Lo, iv.Hi)
+	}
+
+	return u
+}
+
+// Complement returns a new IntervalSet whose values are in [lo, hi) and not in
+// `s`.
+//
+// For example:
+//
+//	s = {[2, 3), [5, 6)}
+//	s.Complement(0, 10) = {[0, 2), [3, 5), [6, 10)}
+func (s *IntervalSet[V]) Complement(lo, hi V) *IntervalSet[V] {
+	return NewIntervalSet(Interval[V]{lo, hi}).Difference(s)
+}
+
+// ComplementClosed is like Complement but over the values in [lo, hi], so that
+// for example the complement of a set of ports may include port 65535.
+func (s *IntervalSet[V]) ComplementClosed(lo, hi V) *IntervalSet[V] {
+	u := &IntervalSet[V]{}
+	u.InsertClosed(lo, hi)
+
+	return u.Difference(s)
+}
+
+// search returns the index of the first interval satisfying `f`, which must be
+// false and then true over the intervals of `s`.
+func (s *IntervalSet[V]) search(f func(Interval[V]) bool) int {
+	return sort.Search(len(s.ivs), func(i int) bool { return f(s.ivs[i]) })
+}
+
+// successor returns the least value greater than `v`, or false if `v` is the
+// greatest value of V, which is the case of the greatest value of an integer
+// type and of +Inf. The successor of a string is the string followed by a
+// zero byte.
+func successor[V cmp.Ordered](v V) (V, bool) {
+	if isGreatest(v) {
+		return v, false
+	}
+
+	r := reflect.ValueOf(&v).Elem()
+
+	switch r.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		r.SetInt(r.Int() + 1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		r.SetUint(r.Uint() + 1)
+	case reflect.Float32:
+		r.SetFloat(float64(math.Nextafter32(float32(r.Float()), float32(math.Inf(1)))))
+	case reflect.Float64:
+		r.SetFloat(math.Nextafter(r.Float(), math.Inf(1)))
+	case reflect.String:
+		r.SetString(r.String() + "\x00")
+	}
+
+	return v, true
+}
+
+// isGreatest returns true iff `v` is the greatest value of V.
+func isGreatest[V cmp.Ordered](v V) bool {
+	g, ok := greatest[V]()
+	return ok && v == g
+}
+
+// greatest returns the greatest value of V, or false if there is none, which
+// is the case of strings.
+func greatest[V cmp.Ordered]() (V, bool) {
+	var v V
+	r := reflect.ValueOf(&v).Elem()
+
+	switch r.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		r.SetInt(math.MaxInt64 >> (64 - r.Type().Bits()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		r.SetUint(math.MaxUint64 >> (64 - r.Type().Bits()))
+	case reflect.Float32, reflect.Float64:
+		r.SetFloat(math.Inf(1))
+	default:
+		return v, false
+	}
+
+	return v, true
+}
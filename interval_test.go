@@ -0,0 +1,407 @@
+package set_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+type iv = set.Interval[int]
+
+func TestIntervalSetInsert(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.IntervalSet[int]
+		v    iv
+		want []iv
+	}{
+		{
+			name: "insert disjoint",
+			s:    set.NewIntervalSet(iv{1, 3}),
+			v:    iv{5, 7},
+			want: []iv{{1, 3}, {5, 7}},
+		},
+		{
+			name: "insert adjacent",
+			s:    set.NewIntervalSet(iv{1, 3}, iv{5, 7}),
+			v:    iv{3, 5},
+			want: []iv{{1, 7}},
+		},
+		{
+			name: "insert overlapping",
+			s:    set.NewIntervalSet(iv{1, 3}, iv{5, 7}, iv{9, 10}),
+			v:    iv{2, 6},
+			want: []iv{{1, 7}, {9, 10}},
+		},
+		{
+			name: "insert empty",
+			s:    set.NewIntervalSet(iv{1, 3}),
+			v:    iv{5, 5},
+			want: []iv{{1, 3}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.s.Insert(tt.v.Lo, tt.v.Hi)
+
+			if diff := cmp.Diff(tt.want, tt.s.Intervals()); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIntervalSetDelete(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.IntervalSet[int]
+		v    iv
+		want []iv
+	}{
+		{
+			name: "delete middle",
+			s:    set.NewIntervalSet(iv{1, 10}),
+			v:    iv{3, 5},
+			want: []iv{{1, 3}, {5, 10}},
+		},
+		{
+			name: "delete across intervals",
+			s:    set.NewIntervalSet(iv{1, 3}, iv{5, 7}, iv{9, 12}),
+			v:    iv{2, 10},
+			want: []iv{{1, 2}, {10, 12}},
+		},
+		{
+			name: "delete gap",
+			s:    set.NewIntervalSet(iv{1, 3}, iv{5, 7}),
+			v:    iv{3, 5},
+			want: []iv{{1, 3}, {5, 7}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.s.Delete(tt.v.Lo, tt.v.Hi)
+
+			if diff := cmp.Diff(tt.want, tt.s.Intervals()); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIntervalSetHas(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.IntervalSet[int]
+		v    int
+		want bool
+	}{
+		{
+			name: "has lower bound",
+			s:    set.NewIntervalSet(iv{1, 3}),
+			v:    1,
+			want: true,
+		},
+		{
+			name: "not has upper bound",
+			s:    set.NewIntervalSet(iv{1, 3}),
+			v:    3,
+			want: false,
+		},
+		{
+			name: "not has empty",
+			s:    set.NewIntervalSet[int](),
+			v:    0,
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, tt.s.Has(tt.v)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIntervalSetUnion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.IntervalSet[int]
+		t    *set.IntervalSet[int]
+		want *set.IntervalSet[int]
+	}{
+		{
+			name: "union",
+			s:    set.NewIntervalSet(iv{1, 3}, iv{5, 7}),
+			t:    set.NewIntervalSet(iv{3, 4}),
+			want: set.NewIntervalSet(iv{1, 4}, iv{5, 7}),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, tt.s.Union(tt.t)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIntervalSetIntersection(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.IntervalSet[int]
+		t    *set.IntervalSet[int]
+		want *set.IntervalSet[int]
+	}{
+		{
+			name: "intersection",
+			s:    set.NewIntervalSet(iv{1, 5}),
+			t:    set.NewIntervalSet(iv{0, 2}, iv{4, 8}),
+			want: set.NewIntervalSet(iv{1, 2}, iv{4, 5}),
+		},
+		{
+			name: "intersection disjoint",
+			s:    set.NewIntervalSet(iv{1, 3}),
+			t:    set.NewIntervalSet(iv{3, 5}),
+			want: set.NewIntervalSet[int](),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, tt.s.Intersection(tt.t)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIntervalSetComplement(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.IntervalSet[int]
+		v    iv
+		want *set.IntervalSet[int]
+	}{
+		{
+			name: "complement",
+			s:    set.NewIntervalSet(iv{2, 3}, iv{5, 6}),
+			v:    iv{0, 10},
+			want: set.NewIntervalSet(iv{0, 2}, iv{3, 5}, iv{6, 10}),
+		},
+		{
+			name: "complement of a superset",
+			s:    set.NewIntervalSet(iv{0, 10}),
+			v:    iv{2, 4},
+			want: set.NewIntervalSet[int](),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, tt.s.Complement(tt.v.Lo, tt.v.Hi)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIntervalSetString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.IntervalSet[int]
+		want string
+	}{
+		{
+			name: "string",
+			s:    set.NewIntervalSet(iv{1, 3}, iv{5, 7}),
+			want: "[[1, 3) [5, 7)]",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, tt.s.String()); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIntervalSetClosed(t *testing.T) {
+	t.Parallel()
+
+	ports := func(lo, hi uint16) *set.IntervalSet[uint16] {
+		s := set.NewIntervalSet[uint16]()
+		s.InsertClosed(lo, hi)
+		return s
+	}
+
+	tests := []struct {
+		name       string
+		s          *set.IntervalSet[uint16]
+		has, hasNo []uint16
+		want       string
+	}{
+		{
+			name:  "insert closed",
+			s:     ports(1024, math.MaxUint16),
+			has:   []uint16{1024, math.MaxUint16 - 1, math.MaxUint16},
+			hasNo: []uint16{1023},
+			want:  "[[1024, 65535]]",
+		},
+		{
+			name:  "insert closed below the greatest value",
+			s:     ports(80, 80),
+			has:   []uint16{80},
+			hasNo: []uint16{79, 81, math.MaxUint16},
+			want:  "[[80, 81)]",
+		},
+		{
+			name:  "insert the greatest value only",
+			s:     ports(math.MaxUint16, math.MaxUint16),
+			has:   []uint16{math.MaxUint16},
+			hasNo: []uint16{math.MaxUint16 - 1},
+			want:  "[[65535, 65535]]",
+		},
+		{
+			name: "delete closed",
+			s: func() *set.IntervalSet[uint16] {
+				s := ports(1024, math.MaxUint16)
+				s.DeleteClosed(2048, math.MaxUint16)
+				return s
+			}(),
+			has:   []uint16{2047},
+			hasNo: []uint16{2048, math.MaxUint16},
+			want:  "[[1024, 2048)]",
+		},
+		{
+			name:  "complement closed",
+			s:     set.NewIntervalSet(set.Interval[uint16]{0, 1024}).ComplementClosed(0, math.MaxUint16),
+			has:   []uint16{1024, math.MaxUint16},
+			hasNo: []uint16{1023},
+			want:  "[[1024, 65535]]",
+		},
+		{
+			name:  "complement excludes its upper bound",
+			s:     set.NewIntervalSet(set.Interval[uint16]{0, 1024}).Complement(0, math.MaxUint16),
+			has:   []uint16{1024},
+			hasNo: []uint16{math.MaxUint16},
+			want:  "[[1024, 65535)]",
+		},
+		{
+			name:  "union",
+			s:     ports(0, 10).Union(ports(math.MaxUint16, math.MaxUint16)),
+			has:   []uint16{10, math.MaxUint16},
+			hasNo: []uint16{11},
+			want:  "[[0, 11) [65535, 65535]]",
+		},
+		{
+			name:  "intersection",
+			s:     ports(0, math.MaxUint16).Intersection(ports(10, 20)),
+			has:   []uint16{10, 20},
+			hasNo: []uint16{math.MaxUint16},
+			want:  "[[10, 21)]",
+		},
+		{
+			name:  "difference",
+			s:     ports(0, math.MaxUint16).Difference(ports(10, 20)),
+			has:   []uint16{9, 21, math.MaxUint16},
+			hasNo: []uint16{10, 20},
+			want:  "[[0, 10) [21, 65535]]",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			for _, v := range tt.has {
+				if !tt.s.Has(v) {
+					t.Errorf("got no %v in %v", v, tt.s)
+				}
+			}
+			for _, v := range tt.hasNo {
+				if tt.s.Has(v) {
+					t.Errorf("got %v in %v", v, tt.s)
+				}
+			}
+			if diff := cmp.Diff(tt.want, tt.s.String()); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIntervalSetClosedTypes(t *testing.T) {
+	t.Parallel()
+
+	i := set.NewIntervalSet[int8]()
+	i.InsertClosed(math.MinInt8, math.MaxInt8)
+	if !i.Has(math.MinInt8) || !i.Has(math.MaxInt8) {
+		t.Errorf("got %v, want all the values of int8", i)
+	}
+
+	f := set.NewIntervalSet[float64]()
+	f.InsertClosed(1, math.Inf(1))
+	if !f.Has(1) || !f.Has(math.Inf(1)) || f.Has(math.Nextafter(1, 0)) {
+		t.Errorf("got %v, want [1, +Inf]", f)
+	}
+
+	s := set.NewIntervalSet[string]()
+	s.InsertClosed("a", "c")
+	if !s.Has("a") || !s.Has("c") || s.Has("ca") {
+		t.Errorf("got %v, want [a, c]", s)
+	}
+}
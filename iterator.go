@@ -0,0 +1,63 @@
+package set
+
+import "iter"
+
+// Iterator iterates over the values of a Set with the Set locked for reading,
+// so that the iteration sees a consistent state without copying the values.
+// It must be closed to unlock the Set.
+//
+// An Iterator is not safe for concurrent use.
+type Iterator[V comparable] struct {
+	s    *Set[V]
+	next func() (V, bool)
+	stop func()
+}
+
+// Iter returns an Iterator over the values of `s`, which is locked for reading
+// until the Iterator is closed or has returned all the values:
+//
+//	it := s.Iter()
+//	defer it.Close()
+//
+//	for v, ok := it.Next(); ok; v, ok = it.Next() {
+//		// Use v.
+//	}
+//
+// Since `s` stays locked, the goroutine holding the Iterator must not modify
+// `s` before closing it, which would deadlock. As with sync.RWMutex, calling
+// the other methods of `s` in the meantime may deadlock as well once another
+// goroutine waits to modify `s`. Use All to modify `s` during an iteration.
+func (s *Set[V]) Iter() *Iterator[V] {
+	s.mu.RLock()
+
+	next, stop := iter.Pull(ordered(s.values()))
+
+	return &Iterator[V]{s: s, next: next, stop: stop}
+}
+
+// Next returns the next value, and false once all the values have been
+// returned or the Iterator is closed.
+func (it *Iterator[V]) Next() (V, bool) {
+	if it.s == nil {
+		var zero V
+		return zero, false
+	}
+
+	v, ok := it.next()
+	if !ok {
+		it.Close()
+	}
+
+	return v, ok
+}
+
+// Close unlocks the Set. It may be called more than once.
+func (it *Iterator[V]) Close() {
+	if it.s == nil {
+		return
+	}
+
+	it.stop()
+	it.s.mu.RUnlock()
+	it.s = nil
+}
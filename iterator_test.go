@@ -0,0 +1,93 @@
+package set_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestSetIter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.Set[int]
+	}{
+		{
+			name: "empty",
+			s:    set.New[int](),
+		},
+		{
+			name: "small",
+			s:    set.New(3, 1, 2),
+		},
+		{
+			name: "large",
+			s:    rangeSet(0, 100),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			it := tt.s.Iter()
+			defer it.Close()
+
+			var got []int
+			for v, ok := it.Next(); ok; v, ok = it.Next() {
+				got = append(got, v)
+			}
+			slices.Sort(got)
+
+			if diff := cmp.Diff(sortedValues(tt.s), got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+
+			// The set is unlocked once all the values are returned.
+			tt.s.Insert(-1)
+
+			if _, ok := it.Next(); ok {
+				t.Error("got a value after the end of the iteration")
+			}
+		})
+	}
+}
+
+func TestSetIterClose(t *testing.T) {
+	t.Parallel()
+
+	s := rangeSet(0, 100)
+
+	it := s.Iter()
+	if _, ok := it.Next(); !ok {
+		t.Fatal("got no value")
+	}
+
+	inserted := make(chan struct{})
+	go func() {
+		s.Insert(-1)
+		close(inserted)
+	}()
+
+	select {
+	case <-inserted:
+		t.Fatal("got the set modified while an Iterator is open")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	it.Close()
+	it.Close()
+	<-inserted
+
+	if _, ok := it.Next(); ok {
+		t.Error("got a value after Close")
+	}
+}
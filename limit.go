@@ -0,0 +1,95 @@
+package set
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrFull is returned by TryInsert when a value would grow a Set past the
+// maximum length set by WithMaxLen with the OverflowError policy.
+var ErrFull = errors.New("set: set is at its maximum length")
+
+// OverflowPolicy defines what happens when a value is inserted into a Set at
+// the maximum length set by WithMaxLen.
+type OverflowPolicy int
+
+const (
+	// OverflowError rejects the value, and TryInsert returns ErrFull.
+	OverflowError OverflowPolicy = iota
+
+	// OverflowDrop drops the value silently.
+	OverflowDrop
+
+	// OverflowEvict deletes an arbitrary value of the Set to make room for
+	// the new one, calling the OnDelete hooks.
+	OverflowEvict
+)
+
+// WithValidator makes a Set reject the values for which `f` returns an error.
+// TryInsert returns the error, while Insert and the other methods inserting
+// values skip them silently.
+//
+// `f` is called with the Set locked, so it must not call its methods.
+func WithValidator[V comparable](f func(V) error) Option[V] {
+	return func(c *config[V]) {
+		c.validator = f
+	}
+}
+
+// WithMaxLen makes a Set hold at most `n` values, applying `policy` to the
+// values inserted past that. Invalid values are rejected first.
+//
+// WithMaxLen panics if `n` is less than 1.
+func WithMaxLen[V comparable](n int, policy OverflowPolicy) Option[V] {
+	if n < 1 {
+		panic("set: maximum length must be at least 1")
+	}
+
+	return func(c *config[V]) {
+		c.maxLen = n
+		c.overflow = policy
+	}
+}
+
+// TryInsert is like Insert but returns an error for the first value rejected
+// by the validator set by WithValidator, or by the maximum length set by
+// WithMaxLen. The values before it are inserted, and the values after it are
+// not.
+func (s *Set[V]) TryInsert(v ...V) error {
+	defer s.unlock(s.lock("TryInsert"))
+
+	s.beginStep()
+	defer s.endStep()
+
+	for _, x := range v {
+		if _, err := s.tryInsert(x); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// admit returns true iff `v`, which is not in `s`, may be inserted into it,
+// evicting a value to make room if needed, or an error if `v` is rejected.
+func (s *Set[V]) admit(v V) (bool, error) {
+	if s.cfg.validator != nil {
+		if err := s.cfg.validator(v); err != nil {
+			return false, fmt.Errorf("set: invalid value %v: %w", v, err)
+		}
+	}
+
+	if s.cfg.maxLen == 0 || s.size() < s.cfg.maxLen {
+		return true, nil
+	}
+
+	switch s.cfg.overflow {
+	case OverflowDrop:
+		return false, nil
+	case OverflowEvict:
+		s.popAny()
+		return true, nil
+	default:
+		return false, ErrFull
+	}
+}
@@ -0,0 +1,137 @@
+package set_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+var errNegative = errors.New("negative")
+
+func nonNegative(v int) error {
+	if v < 0 {
+		return errNegative
+	}
+	return nil
+}
+
+func TestWithValidator(t *testing.T) {
+	t.Parallel()
+
+	s := set.NewWithOptions(set.WithValidator(nonNegative))
+
+	s.Insert(1, -1, 2)
+	if diff := cmp.Diff([]int{1, 2}, sortedValues(s)); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+
+	if err := s.TryInsert(3, -2, 4); !errors.Is(err, errNegative) {
+		t.Errorf("got error %v, want %v", err, errNegative)
+	}
+	if diff := cmp.Diff([]int{1, 2, 3}, sortedValues(s)); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+
+	if err := s.TryInsert(5); err != nil {
+		t.Errorf("got error %v", err)
+	}
+}
+
+func TestWithMaxLen(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		policy  set.OverflowPolicy
+		wantErr error
+		wantLen int
+		wantNew bool
+	}{
+		{
+			name:    "error",
+			policy:  set.OverflowError,
+			wantErr: set.ErrFull,
+			wantLen: 20,
+			wantNew: false,
+		},
+		{
+			name:    "drop",
+			policy:  set.OverflowDrop,
+			wantLen: 20,
+			wantNew: false,
+		},
+		{
+			name:    "evict",
+			policy:  set.OverflowEvict,
+			wantLen: 20,
+			wantNew: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var deleted int
+
+			s := set.NewWithOptions(set.WithMaxLen[int](20, tt.policy))
+			s.OnDelete(func(int) { deleted++ })
+
+			for i := 0; i < 20; i++ {
+				if err := s.TryInsert(i); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// Inserting a value already there never overflows.
+			if err := s.TryInsert(0); err != nil {
+				t.Errorf("got error %v inserting an existing value", err)
+			}
+
+			if err := s.TryInsert(100); !errors.Is(err, tt.wantErr) {
+				t.Errorf("got error %v, want %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantNew, s.Contains(100)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+
+			// Evicting may delete 100, so only the last value is checked.
+			s.Insert(101)
+			if diff := cmp.Diff(tt.wantNew, s.Contains(101)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.wantLen, s.Len()); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+
+			wantDeleted := 0
+			if tt.wantNew {
+				wantDeleted = 2
+			}
+			if diff := cmp.Diff(wantDeleted, deleted); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWithMaxLenValidator(t *testing.T) {
+	t.Parallel()
+
+	s := set.NewWithOptions(set.WithValidator(nonNegative), set.WithMaxLen[int](1, set.OverflowEvict))
+	s.Insert(1)
+
+	// An invalid value does not evict a valid one.
+	if err := s.TryInsert(-1); !errors.Is(err, errNegative) {
+		t.Errorf("got error %v, want %v", err, errNegative)
+	}
+	if diff := cmp.Diff([]int{1}, sortedValues(s)); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
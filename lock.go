@@ -0,0 +1,150 @@
+package set
+
+import (
+	"cmp"
+	"slices"
+	"time"
+	"unsafe"
+)
+
+// call describes a call to a method of a Set holding its lock, from the
+// acquisition of the lock to its release.
+type call[V comparable] struct {
+	// method is the name of the method if the Set has an observer, and
+	// empty otherwise.
+	method string
+	start  time.Time
+	wait   time.Duration
+
+	// t is the other Set locked for reading by the call, if any.
+	t *Set[V]
+}
+
+// lock locks `s` for writing on behalf of `method` and returns the call to
+// pass to unlock.
+func (s *Set[V]) lock(method string) call[V] {
+	var c call[V]
+
+	if !s.mu.TryLock() {
+		c.start = time.Now()
+		s.mu.Lock()
+		c.wait = time.Since(c.start)
+	}
+
+	return s.observed(c, method)
+}
+
+// unlock unlocks `s` locked by lock and reports the call to the observer of
+// `s`, if any.
+func (s *Set[V]) unlock(c call[V]) {
+	o, ob := s.observation(c)
+
+	s.mu.Unlock()
+
+	if o != nil {
+		o.Observe(ob)
+	}
+}
+
+// rlock locks `s`, and `t` unless it is nil, for reading on behalf of
+// `method` and returns the call to pass to runlock.
+//
+// The sets are locked in a fixed order so that concurrent calls locking the
+// same sets, such as s.Union(t) and t.Union(s), cannot deadlock.
+func (s *Set[V]) rlock(method string, t *Set[V]) call[V] {
+	var c call[V]
+
+	if t != s {
+		c.t = t
+	}
+
+	first, second := s, c.t
+	if second != nil && uintptr(unsafe.Pointer(second)) < uintptr(unsafe.Pointer(first)) {
+		first, second = second, first
+	}
+
+	for _, u := range [2]*Set[V]{first, second} {
+		if u == nil || u.mu.TryRLock() {
+			continue
+		}
+
+		if c.start.IsZero() {
+			c.start = time.Now()
+		}
+		u.mu.RLock()
+	}
+
+	if !c.start.IsZero() {
+		c.wait = time.Since(c.start)
+	}
+
+	return s.observed(c, method)
+}
+
+// runlock unlocks the sets locked by rlock and reports the call to the
+// observer of `s`, if any.
+func (s *Set[V]) runlock(c call[V]) {
+	o, ob := s.observation(c)
+
+	if c.t != nil {
+		c.t.mu.RUnlock()
+	}
+	s.mu.RUnlock()
+
+	if o != nil {
+		o.Observe(ob)
+	}
+}
+
+// observed sets the method of `c` if `s`, now locked, has an observer.
+func (s *Set[V]) observed(c call[V], method string) call[V] {
+	if s.cfg == nil || s.cfg.observer == nil {
+		return c
+	}
+
+	c.method = method
+	if c.start.IsZero() {
+		c.start = time.Now()
+	}
+
+	return c
+}
+
+// observation returns the observer of `s`, still locked, along with the
+// Observation of `c`, or nil if `c` is not observed.
+func (s *Set[V]) observation(c call[V]) (Observer, Observation) {
+	if c.method == "" {
+		return nil, Observation{}
+	}
+
+	return s.cfg.observer, Observation{
+		Method:   c.method,
+		Duration: time.Since(c.start),
+		Wait:     c.wait,
+	}
+}
+
+// rlockAll locks the given sets for reading in a fixed order, as rlock does,
+// and returns a function unlocking them. Sets may be repeated or nil.
+func rlockAll[V comparable](sets ...*Set[V]) (unlock func()) {
+	locked := make([]*Set[V], 0, len(sets))
+	for _, s := range sets {
+		if s != nil && !slices.Contains(locked, s) {
+			locked = append(locked, s)
+		}
+	}
+
+	slices.SortFunc(locked, func(a, b *Set[V]) int {
+		return cmp.Compare(uintptr(unsafe.Pointer(a)), uintptr(unsafe.Pointer(b)))
+	})
+
+	for _, s := range locked {
+		s.mu.RLock()
+	}
+
+	return func() {
+		for _, s := range locked {
+			s.mu.RUnlock()
+		}
+	}
+}
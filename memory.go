@@ -0,0 +1,120 @@
+package set
+
+import (
+	"math/bits"
+	"slices"
+	"unsafe"
+)
+
+const (
+	// minAutoCompactPeak is the peak length below which auto-compaction is
+	// skipped, since small maps hold little memory.
+	minAutoCompactPeak = 1024
+
+	// mapGroupSlots and mapMaxLoad mirror the layout of Go's swiss-table
+	// maps: slots are grouped by 8 behind an 8-byte control word, and tables
+	// grow when they are 7/8 full.
+	mapGroupSlots = 8
+	mapMaxLoad    = 7.0 / 8
+	mapCtrlBytes  = 8
+	mapHeader     = 48
+)
+
+// SizeBytes returns the approximate heap memory used by `s`, including the
+// overhead of the underlying map.
+//
+// Go maps never shrink, so the estimate is based on the largest length `s` has
+// reached rather than its current length; see Compact to reclaim that memory.
+// Memory referenced by the values, such as the bytes of strings, is not
+// counted; see SizeBytesFunc.
+func (s *Set[V]) SizeBytes() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var v V
+
+	if s.m == nil {
+		return int64(unsafe.Sizeof(*s)) + int64(cap(s.small))*int64(unsafe.Sizeof(v))
+	}
+
+	n := max(s.peak, len(s.m))
+
+	slots := int64(1)
+	if n > 0 {
+		groups := uint64(float64(n)/mapMaxLoad/mapGroupSlots) + 1
+		slots = int64(1<<bits.Len64(groups-1)) * mapGroupSlots
+	}
+
+	size := int64(unsafe.Sizeof(*s)) + mapHeader
+	size += slots * int64(unsafe.Sizeof(v))
+	size += slots / mapGroupSlots * mapCtrlBytes
+
+	return size
+}
+
+// SizeBytesFunc is like SizeBytes but adds the result of calling `f` on each
+// value of `s`, so that memory referenced by the values can be accounted for.
+//
+// For example, for a Set[string]:
+//
+//	s.SizeBytesFunc(func(v string) int64 { return int64(len(v)) })
+func (s *Set[V]) SizeBytesFunc(f func(V) int64) int64 {
+	size := s.SizeBytes()
+
+	for k := range s.All() {
+		size += f(k)
+	}
+
+	return size
+}
+
+// Compact rebuilds the underlying storage of `s` sized for its current length,
+// releasing the memory held since `s` was at its largest.
+//
+// Go maps never shrink, so a Set that once held many values keeps their memory
+// after they are deleted until Compact is called.
+func (s *Set[V]) Compact() {
+	defer s.unlock(s.lock("Compact"))
+
+	s.compact()
+}
+
+// compact rebuilds the underlying storage of `s`.
+func (s *Set[V]) compact() {
+	if s.size() <= smallLen {
+		s.small = slices.AppendSeq(make([]V, 0, s.size()), s.values())
+		s.m = nil
+		s.peak = 0
+		return
+	}
+
+	m := make(map[V]struct{}, len(s.m))
+	for k := range s.m {
+		m[k] = struct{}{}
+	}
+
+	s.m = m
+	s.peak = len(m)
+}
+
+// WithAutoCompact makes a Set compact itself when a deletion leaves it with
+// fewer than `ratio` times the largest length it has reached, for example 0.25
+// to compact once three quarters of the values are gone.
+//
+// Sets that never grew past a thousand values are not compacted.
+func WithAutoCompact[V comparable](ratio float64) Option[V] {
+	return func(c *config[V]) {
+		c.autoCompact = ratio
+	}
+}
+
+// maybeCompact compacts `s` if it is configured to and has shrunk enough.
+func (s *Set[V]) maybeCompact() {
+	if s.cfg == nil || s.peak < minAutoCompactPeak {
+		return
+	}
+
+	if float64(s.size()) < s.cfg.autoCompact*float64(s.peak) {
+		s.compact()
+	}
+}
@@ -0,0 +1,118 @@
+package set_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestSetSizeBytes(t *testing.T) {
+	t.Parallel()
+
+	small, large := rangeSet(0, 10).SizeBytes(), rangeSet(0, 10000).SizeBytes()
+
+	if small <= 0 || large <= small {
+		t.Errorf("got sizes %d and %d, want 0 < small < large", small, large)
+	}
+
+	// 10000 ints take 80 KB of keys, plus the map overhead.
+	if large < 80000 || large > 4*80000 {
+		t.Errorf("got size %d for 10000 ints", large)
+	}
+
+	s := rangeSet(0, 10000)
+	for i := 0; i < 10000; i++ {
+		s.Delete(i)
+	}
+
+	if diff := cmp.Diff(large, s.SizeBytes()); diff != "" {
+		t.Errorf("size after deletions (-want +got):\n%s", diff)
+	}
+}
+
+func TestSetSizeBytesFunc(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.Set[string]
+		want int64
+	}{
+		{
+			name: "strings",
+			s:    set.New("foo", "quux"),
+			want: 7,
+		},
+		{
+			name: "empty",
+			s:    set.New[string](),
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tt.s.SizeBytesFunc(func(v string) int64 { return int64(len(v)) })
+
+			if diff := cmp.Diff(tt.want, got-tt.s.SizeBytes()); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetCompact(t *testing.T) {
+	t.Parallel()
+
+	s := rangeSet(0, 10000)
+	for i := 100; i < 10000; i++ {
+		s.Delete(i)
+	}
+
+	before, version := s.SizeBytes(), s.Version()
+	s.Compact()
+
+	if after := s.SizeBytes(); after >= before/10 {
+		t.Errorf("got size %d after compaction, want less than %d", after, before/10)
+	}
+	if diff := cmp.Diff(rangeSet(0, 100), s); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(version, s.Version()); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
+
+func TestWithAutoCompact(t *testing.T) {
+	t.Parallel()
+
+	s := set.NewWithOptions(set.WithAutoCompact[int](0.25))
+	for i := 0; i < 10000; i++ {
+		s.Insert(i)
+	}
+
+	full := s.SizeBytes()
+
+	for i := 0; i < 7000; i++ {
+		s.Delete(i)
+	}
+	if diff := cmp.Diff(full, s.SizeBytes()); diff != "" {
+		t.Errorf("compacted above the ratio (-want +got):\n%s", diff)
+	}
+
+	for i := 7000; i < 7600; i++ {
+		s.Delete(i)
+	}
+	if got := s.SizeBytes(); got >= full/2 {
+		t.Errorf("got size %d after shrinking, want less than %d", got, full/2)
+	}
+	if diff := cmp.Diff(rangeSet(7600, 10000), s); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
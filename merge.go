@@ -0,0 +1,51 @@
+package set
+
+// Conflicts holds the values that a three-way merge resolved without both
+// sides agreeing, for callers that want to review them.
+type Conflicts[V comparable] struct {
+	// OneSidedRemovals holds the values of the base that one side removed
+	// and the other left unchanged. They are not true conflicts, and Merge3
+	// removes them; inserting them back into the result gives add-wins
+	// semantics instead.
+	OneSidedRemovals *Set[V]
+}
+
+// Merge3 returns the three-way merge of `ours` and `theirs`, two sets derived
+// from `base`.
+//
+// A value added by either side is in the result, and a value of `base` is in
+// the result only if neither side removed it. Since membership has no other
+// changes, the two sides never truly conflict; the values that only one side
+// removed are reported in Conflicts.
+//
+// For example:
+//
+//	base   = {a1, a2, a3}
+//	ours   = {a1, a2, a4}
+//	theirs = {a1, a3, a5}
+//	set.Merge3(base, ours, theirs) = {a1, a4, a5}, {OneSidedRemovals: {a2, a3}}
+func Merge3[V comparable](base, ours, theirs *Set[V]) (*Set[V], Conflicts[V]) {
+	defer rlockAll(base, ours, theirs)()
+
+	u := New[V]()
+	c := Conflicts[V]{OneSidedRemovals: New[V]()}
+
+	for k := range base.values() {
+		switch inOurs, inTheirs := ours.has(k), theirs.has(k); {
+		case inOurs && inTheirs:
+			u.insert(k)
+		case inOurs || inTheirs:
+			c.OneSidedRemovals.insert(k)
+		}
+	}
+
+	for _, side := range []*Set[V]{ours, theirs} {
+		for k := range side.values() {
+			if !base.has(k) {
+				u.insert(k)
+			}
+		}
+	}
+
+	return u, c
+}
@@ -0,0 +1,72 @@
+package set_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestMerge3(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		base          *set.Set[int]
+		ours          *set.Set[int]
+		theirs        *set.Set[int]
+		want          *set.Set[int]
+		wantConflicts set.Conflicts[int]
+	}{
+		{
+			name:          "unchanged",
+			base:          set.New(1, 2),
+			ours:          set.New(1, 2),
+			theirs:        set.New(1, 2),
+			want:          set.New(1, 2),
+			wantConflicts: set.Conflicts[int]{OneSidedRemovals: set.New[int]()},
+		},
+		{
+			name:          "both sides changed",
+			base:          set.New(1, 2, 3),
+			ours:          set.New(1, 2, 4),
+			theirs:        set.New(1, 3, 5),
+			want:          set.New(1, 4, 5),
+			wantConflicts: set.Conflicts[int]{OneSidedRemovals: set.New(2, 3)},
+		},
+		{
+			name:          "same changes",
+			base:          set.New(1, 2),
+			ours:          set.New(2, 3),
+			theirs:        set.New(2, 3),
+			want:          set.New(2, 3),
+			wantConflicts: set.Conflicts[int]{OneSidedRemovals: set.New[int]()},
+		},
+		{
+			name:          "empty base",
+			base:          set.New[int](),
+			ours:          set.New(1),
+			theirs:        set.New(2),
+			want:          set.New(1, 2),
+			wantConflicts: set.Conflicts[int]{OneSidedRemovals: set.New[int]()},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, gotConflicts := set.Merge3(tt.base, tt.ours, tt.theirs)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantConflicts, gotConflicts); diff != "" {
+				t.Errorf("conflicts (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package set
+
+import (
+	"expvar"
+)
+
+// Metrics receives counts of the operations performed on a Set.
+//
+// Implementations are called synchronously from the Set's methods, possibly
+// from several goroutines at once, so they should be cheap and safe for
+// concurrent use; counters from a metrics library are a good fit.
+type Metrics interface {
+	// Insert is called when a value is inserted.
+	Insert()
+	// Delete is called when a value is deleted.
+	Delete()
+	// Hit is called when Contains finds a value.
+	Hit()
+	// Miss is called when Contains does not find a value.
+	Miss()
+}
+
+// Instrument makes `s` report its operations to `m`. Contains also counts the
+// lookups made by operations built on it, such as ContainsAll or the methods
+// taking `s` as their argument.
+//
+// Passing nil removes the instrumentation.
+func (s *Set[V]) Instrument(m Metrics) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.config().metrics = m
+}
+
+// ExpvarMetrics is a Metrics publishing the counters of a Set as an
+// expvar.Map with the keys "len", "inserts", "deletes", "hits" and "misses".
+type ExpvarMetrics struct {
+	len, inserts, deletes, hits, misses expvar.Int
+}
+
+// PublishExpvar instruments `s` with an ExpvarMetrics published under `name`
+// and returns it.
+//
+// Like expvar.Publish, PublishExpvar panics if `name` is already in use.
+func PublishExpvar[V comparable](name string, s *Set[V]) *ExpvarMetrics {
+	m := &ExpvarMetrics{}
+
+	v := new(expvar.Map)
+	v.Set("len", &m.len)
+	v.Set("inserts", &m.inserts)
+	v.Set("deletes", &m.deletes)
+	v.Set("hits", &m.hits)
+	v.Set("misses", &m.misses)
+	expvar.Publish(name, v)
+
+	// The length is read under the same lock as `s` is instrumented, so that
+	// no insert or delete is missed in between.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m.len.Set(int64(s.size()))
+	s.config().metrics = m
+
+	return m
+}
+
+// Insert implements Metrics.
+func (m *ExpvarMetrics) Insert() {
+	m.len.Add(1)
+	m.inserts.Add(1)
+}
+
+// Delete implements Metrics.
+func (m *ExpvarMetrics) Delete() {
+	m.len.Add(-1)
+	m.deletes.Add(1)
+}
+
+// Hit implements Metrics.
+func (m *ExpvarMetrics) Hit() {
+	m.hits.Add(1)
+}
+
+// Miss implements Metrics.
+func (m *ExpvarMetrics) Miss() {
+	m.misses.Add(1)
+}
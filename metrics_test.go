@@ -0,0 +1,136 @@
+package set_test
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+type countMetrics struct {
+	inserts, deletes, hits, misses int
+}
+
+func (m *countMetrics) Insert() { m.inserts++ }
+func (m *countMetrics) Delete() { m.deletes++ }
+func (m *countMetrics) Hit()    { m.hits++ }
+func (m *countMetrics) Miss()   { m.misses++ }
+
+func TestSetInstrument(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.Set[int]
+		f    func(*set.Set[int])
+		want countMetrics
+	}{
+		{
+			name: "insert and delete",
+			s:    set.New(1),
+			f: func(s *set.Set[int]) {
+				s.Insert(1, 2, 3)
+				s.Delete(3, 4)
+			},
+			want: countMetrics{inserts: 2, deletes: 1},
+		},
+		{
+			name: "contains",
+			s:    set.New(1),
+			f: func(s *set.Set[int]) {
+				s.Contains(1)
+				s.ContainsAll(1, 2)
+			},
+			want: countMetrics{hits: 2, misses: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &countMetrics{}
+			tt.s.Instrument(m)
+
+			tt.f(tt.s)
+
+			if diff := cmp.Diff(tt.want, *m, cmp.AllowUnexported(countMetrics{})); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// expvarNames numbers the names published by the tests, which must be unique
+// across runs of the same test with -count.
+var expvarNames atomic.Int64
+
+func publishExpvar[V comparable](t *testing.T, s *set.Set[V]) string {
+	t.Helper()
+
+	name := fmt.Sprintf("%s-%d", t.Name(), expvarNames.Add(1))
+	set.PublishExpvar(name, s)
+
+	return name
+}
+
+func expvarCounts(t *testing.T, name string) map[string]int {
+	t.Helper()
+
+	var got map[string]int
+	if err := json.Unmarshal([]byte(expvar.Get(name).String()), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	return got
+}
+
+func TestPublishExpvar(t *testing.T) {
+	t.Parallel()
+
+	s := set.New(1, 2)
+	name := publishExpvar(t, s)
+
+	s.Insert(3)
+	s.Delete(1)
+	s.Contains(2)
+	s.Contains(4)
+
+	want := map[string]int{"len": 2, "inserts": 1, "deletes": 1, "hits": 1, "misses": 1}
+
+	if diff := cmp.Diff(want, expvarCounts(t, name)); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
+
+func TestPublishExpvarConcurrentInsert(t *testing.T) {
+	t.Parallel()
+
+	s := set.New[int]()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				s.Insert(i*1000 + j)
+			}
+		}()
+	}
+
+	name := publishExpvar(t, s)
+	wg.Wait()
+
+	if diff := cmp.Diff(s.Len(), expvarCounts(t, name)["len"]); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,6 @@
+//go:build !setdebug
+
+package set
+
+// failFast reports whether the package is built with the setdebug tag.
+const failFast = false
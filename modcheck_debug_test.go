@@ -0,0 +1,80 @@
+//go:build setdebug
+
+package set_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestSetAllConcurrentModification(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		s       *set.Set[int]
+		f       func(*set.Set[int])
+		wantErr error
+	}{
+		{
+			name: "unmodified",
+			s:    rangeSet(0, 100),
+			f: func(s *set.Set[int]) {
+				for range s.All() {
+					s.Insert(0)
+				}
+			},
+		},
+		{
+			name: "insert",
+			s:    rangeSet(0, 100),
+			f: func(s *set.Set[int]) {
+				for k := range s.All() {
+					s.Insert(k + 100)
+				}
+			},
+			wantErr: set.ErrConcurrentModification,
+		},
+		{
+			name: "delete last values",
+			s:    rangeSet(0, 2),
+			f: func(s *set.Set[int]) {
+				for range s.All() {
+					s.Delete(0, 1)
+				}
+			},
+			wantErr: set.ErrConcurrentModification,
+		},
+		{
+			name: "chunks",
+			s:    rangeSet(0, 100),
+			f: func(s *set.Set[int]) {
+				for c := range s.Chunks(10) {
+					s.Delete(c...)
+				}
+			},
+			wantErr: set.ErrConcurrentModification,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got any
+			func() {
+				defer func() { got = recover() }()
+				tt.f(tt.s)
+			}()
+
+			if diff := cmp.Diff(tt.wantErr, got, cmp.Comparer(func(x, y error) bool { return x == y })); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package set
+
+// UnionAll returns a new Set whose values are included in any of the given
+// sets.
+//
+// For example:
+//
+//	s = {a1, a2}
+//	t = {a2, a3}
+//	u = {a4}
+//	set.UnionAll(s, t, u) = {a1, a2, a3, a4}
+func UnionAll[V comparable](sets ...*Set[V]) *Set[V] {
+	defer rlockAll(sets...)()
+
+	var n int
+	for _, s := range sets {
+		n += s.size()
+	}
+
+	u := newSized[V](n)
+
+	for _, s := range sets {
+		for k := range s.values() {
+			u.Insert(k)
+		}
+	}
+
+	return u
+}
+
+// IntersectAll returns a new Set whose values are included in all the given
+// sets. It returns an empty Set if no sets are given.
+//
+// The smallest set is walked, and the result is returned early as soon as any
+// set is empty.
+//
+// For example:
+//
+//	s = {a1, a2, a3}
+//	t = {a2, a3}
+//	u = {a3, a4}
+//	set.IntersectAll(s, t, u) = {a3}
+func IntersectAll[V comparable](sets ...*Set[V]) *Set[V] {
+	if len(sets) == 0 {
+		return New[V]()
+	}
+
+	defer rlockAll(sets...)()
+
+	walk := sets[0]
+	for _, s := range sets[1:] {
+		if s.size() < walk.size() {
+			walk = s
+		}
+	}
+
+	u := New[V]()
+	if walk.size() == 0 {
+		return u
+	}
+
+next:
+	for k := range walk.values() {
+		for _, s := range sets {
+			if s != walk && !s.contains(k) {
+				continue next
+			}
+		}
+
+		u.Insert(k)
+	}
+
+	return u
+}
+
+// DifferenceAll returns a new Set whose values are in `base` and not in any of
+// the `subtract` sets, in a single pass over `base`.
+//
+// For example:
+//
+//	base = {a1, a2, a3, a4}
+//	s = {a1}
+//	t = {a3, a5}
+//	set.DifferenceAll(base, s, t) = {a2, a4}
+func DifferenceAll[V comparable](base *Set[V], subtract ...*Set[V]) *Set[V] {
+	defer rlockAll(append([]*Set[V]{base}, subtract...)...)()
+
+	u := New[V]()
+
+next:
+	for k := range base.values() {
+		for _, s := range subtract {
+			if s.contains(k) {
+				continue next
+			}
+		}
+
+		u.Insert(k)
+	}
+
+	return u
+}
@@ -0,0 +1,118 @@
+package set_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestUnionAll(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		sets []*set.Set[int]
+		want *set.Set[int]
+	}{
+		{
+			name: "union all",
+			sets: []*set.Set[int]{set.New(1, 2), set.New(2, 3), set.New(4)},
+			want: set.New(1, 2, 3, 4),
+		},
+		{
+			name: "no sets",
+			want: set.New[int](),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, set.UnionAll(tt.sets...), cmp.Comparer(equal(t))); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIntersectAll(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		sets []*set.Set[int]
+		want *set.Set[int]
+	}{
+		{
+			name: "intersect all",
+			sets: []*set.Set[int]{set.New(1, 2, 3), set.New(2, 3), set.New(3, 4)},
+			want: set.New(3),
+		},
+		{
+			name: "with empty",
+			sets: []*set.Set[int]{set.New(1, 2, 3), set.New[int]()},
+			want: set.New[int](),
+		},
+		{
+			name: "single",
+			sets: []*set.Set[int]{set.New(1, 2)},
+			want: set.New(1, 2),
+		},
+		{
+			name: "no sets",
+			want: set.New[int](),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, set.IntersectAll(tt.sets...), cmp.Comparer(equal(t))); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDifferenceAll(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		base     *set.Set[int]
+		subtract []*set.Set[int]
+		want     *set.Set[int]
+	}{
+		{
+			name:     "difference all",
+			base:     set.New(1, 2, 3, 4),
+			subtract: []*set.Set[int]{set.New(1), set.New(3, 5)},
+			want:     set.New(2, 4),
+		},
+		{
+			name: "nothing to subtract",
+			base: set.New(1, 2),
+			want: set.New(1, 2),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, set.DifferenceAll(tt.base, tt.subtract...), cmp.Comparer(equal(t))); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package set
+
+import (
+	"time"
+)
+
+// Observation describes a single call to a method of a Set.
+type Observation struct {
+	// Method is the name of the method, such as "Insert".
+	Method string
+	// Duration is the time the call took.
+	Duration time.Duration
+	// Wait is the part of Duration spent waiting for the locks of the sets
+	// involved in the call.
+	Wait time.Duration
+}
+
+// Observer receives an Observation for every call to the observed methods of
+// a Set: ApplyPatch, Clone, Compact, Delete, Difference, Intersection, Equal,
+// Contains, ContainsAll, ContainsAny, Insert, IsSuperset, PopAny, Redo, Reset,
+// Snapshot, TryInsert, TryPop, Undo, Union, Values and AppendValues.
+//
+// Each call is reported once, after the locks of the Set are released, so
+// Observe may call the methods of the Set.
+type Observer interface {
+	Observe(o Observation)
+}
+
+// WithObserver makes a Set report the duration of its method calls to `o`.
+func WithObserver[V comparable](o Observer) Option[V] {
+	return func(c *config[V]) {
+		c.observer = o
+	}
+}
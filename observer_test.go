@@ -0,0 +1,132 @@
+package set_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+type recordObserver struct {
+	mu      sync.Mutex
+	methods []string
+	wait    map[string]time.Duration
+}
+
+func (o *recordObserver) Observe(ob set.Observation) {
+	if ob.Duration < 0 || ob.Wait < 0 || ob.Wait > ob.Duration {
+		panic("invalid duration")
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.methods = append(o.methods, ob.Method)
+
+	if o.wait == nil {
+		o.wait = make(map[string]time.Duration)
+	}
+	o.wait[ob.Method] += ob.Wait
+}
+
+func TestWithObserver(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		f    func(*set.Set[int])
+		want []string
+	}{
+		{
+			name: "insert and delete",
+			f: func(s *set.Set[int]) {
+				s.Insert(1, 2)
+				s.Delete(1)
+			},
+			want: []string{"Insert", "Delete"},
+		},
+		{
+			name: "try",
+			f: func(s *set.Set[int]) {
+				s.TryInsert(1)
+				s.TryPop()
+			},
+			want: []string{"TryInsert", "TryPop"},
+		},
+		{
+			name: "single observation per call",
+			f: func(s *set.Set[int]) {
+				s.ContainsAny(1, 2)
+			},
+			want: []string{"ContainsAny"},
+		},
+		{
+			name: "copies",
+			f: func(s *set.Set[int]) {
+				s.Snapshot()
+				s.Values()
+				s.AppendValues(nil)
+			},
+			want: []string{"Snapshot", "Values", "AppendValues"},
+		},
+		{
+			name: "unobserved methods",
+			f: func(s *set.Set[int]) {
+				s.Len()
+				s.Version()
+			},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			o := &recordObserver{}
+			s := set.NewWithOptions(set.WithObserver[int](o))
+
+			tt.f(s)
+
+			if diff := cmp.Diff(tt.want, o.methods); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWithObserverWait(t *testing.T) {
+	t.Parallel()
+
+	o := &recordObserver{}
+	s := set.NewWithOptions(set.WithObserver[int](o))
+
+	// The hook runs with `s` locked, which makes Contains wait.
+	locked := make(chan struct{})
+	s.OnInsert(func(int) {
+		close(locked)
+		time.Sleep(20 * time.Millisecond)
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		<-locked
+		s.Contains(1)
+	}()
+
+	s.Insert(1)
+	<-done
+
+	if got := o.wait["Contains"]; got < 10*time.Millisecond {
+		t.Errorf("got wait %v for Contains, want at least 10ms", got)
+	}
+	if got := o.wait["Insert"]; got != 0 {
+		t.Errorf("got wait %v for Insert, want 0", got)
+	}
+}
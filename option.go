@@ -0,0 +1,15 @@
+package set
+
+// Option configures a Set created by NewWithOptions.
+type Option[V comparable] func(*config[V])
+
+// NewWithOptions returns an empty Set configured with the given options.
+func NewWithOptions[V comparable](opts ...Option[V]) *Set[V] {
+	s := New[V]()
+
+	for _, opt := range opts {
+		opt(s.config())
+	}
+
+	return s
+}
@@ -0,0 +1,16 @@
+//go:build !setdeterministic
+
+package set
+
+import (
+	"iter"
+)
+
+// deterministic reports whether the package is built with the
+// setdeterministic tag.
+const deterministic = false
+
+// ordered returns `seq` unchanged.
+func ordered[V comparable](seq iter.Seq[V]) iter.Seq[V] {
+	return seq
+}
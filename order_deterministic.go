@@ -0,0 +1,18 @@
+//go:build setdeterministic
+
+package set
+
+import (
+	"iter"
+	"slices"
+)
+
+// deterministic reports whether the package is built with the
+// setdeterministic tag.
+const deterministic = true
+
+// ordered returns an iterator over the values of `seq` in sorted order, as
+// defined by compareValues.
+func ordered[V comparable](seq iter.Seq[V]) iter.Seq[V] {
+	return slices.Values(slices.SortedFunc(seq, compareValues[V]))
+}
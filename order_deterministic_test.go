@@ -0,0 +1,58 @@
+//go:build setdeterministic
+
+package set_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestSetStringDeterministic(t *testing.T) {
+	t.Parallel()
+
+	type point struct{ X, Y int }
+
+	tests := []struct {
+		name string
+		s    interface{ String() string }
+		want string
+	}{
+		{
+			name: "ints",
+			s:    set.New(10, 9, -1, 2),
+			want: "[-1 2 9 10]",
+		},
+		{
+			name: "strings",
+			s:    set.New("foo", "bar", "baz"),
+			want: "[bar baz foo]",
+		},
+		{
+			name: "mixed kinds",
+			s:    set.New[any](2.5, "a", 1, nil, 10, "b"),
+			want: "[<nil> 1 10 2.5 a b]",
+		},
+		{
+			name: "structs",
+			s:    set.New(point{2, 1}, point{1, 2}),
+			want: "[{1 2} {2 1}]",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			for i := 0; i < 10; i++ {
+				if diff := cmp.Diff(tt.want, tt.s.String()); diff != "" {
+					t.Fatalf("(-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
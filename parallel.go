@@ -0,0 +1,117 @@
+package set
+
+import (
+	"maps"
+	"runtime"
+	"sync"
+)
+
+// ParallelThreshold is the size of the walked set below which the parallel
+// variants of set operations run sequentially.
+const ParallelThreshold = 1 << 14
+
+// ParallelDifference is like Difference but splits the membership checks
+// across GOMAXPROCS goroutines when `s` is larger than ParallelThreshold.
+func ParallelDifference[V comparable](s, t *Set[V]) *Set[V] {
+	return parallelFilter(s, t, func(k V) bool { return !t.has(k) })
+}
+
+// ParallelIntersection is like Intersection but splits the membership checks
+// across GOMAXPROCS goroutines when the smaller set is larger than
+// ParallelThreshold.
+func ParallelIntersection[V comparable](s, t *Set[V]) *Set[V] {
+	walk, other := s, t
+	if t.Len() < s.Len() {
+		walk, other = t, s
+	}
+
+	return parallelFilter(walk, other, other.has)
+}
+
+// ParallelUnion is like Union but finds the values of `t` missing from `s`
+// across GOMAXPROCS goroutines when `t` is larger than ParallelThreshold.
+func ParallelUnion[V comparable](s, t *Set[V]) *Set[V] {
+	unlock := rlockAll(s, t)
+
+	v := filterParallel(t.appendValues(make([]V, 0, t.size())), func(k V) bool { return !s.has(k) })
+
+	// A large hash table is copied at once rather than by inserting its
+	// values one by one.
+	var u *Set[V]
+	if s.m != nil && len(s.m) >= ParallelThreshold {
+		u = &Set[V]{m: maps.Clone(s.m)}
+	} else {
+		u = s.clone()
+	}
+
+	unlock()
+
+	u.Insert(v...)
+
+	return u
+}
+
+// parallelFilter returns a new Set of the values of `s` satisfying `keep`,
+// which may look up `t`; see parallelValues.
+func parallelFilter[V comparable](s, t *Set[V], keep func(V) bool) *Set[V] {
+	v := parallelValues(s, t, keep)
+
+	u := newSized[V](len(v))
+	u.Insert(v...)
+
+	return u
+}
+
+// parallelValues returns the values of `s` satisfying `keep`, which is called
+// concurrently on chunks of the values of `s` if `s` is large enough.
+//
+// `s` and `t` are locked for reading once for the whole call, so that `keep`
+// can look values up in `t` without locking it again from every goroutine.
+func parallelValues[V comparable](s, t *Set[V], keep func(V) bool) []V {
+	defer rlockAll(s, t)()
+
+	return filterParallel(s.appendValues(make([]V, 0, s.size())), keep)
+}
+
+// filterParallel is like filterValues but filters chunks of `v` concurrently
+// if `v` is large enough.
+func filterParallel[V comparable](v []V, keep func(V) bool) []V {
+	n := runtime.GOMAXPROCS(0)
+	if len(v) < ParallelThreshold || n == 1 {
+		return filterValues(v, keep)
+	}
+
+	chunks := make([][]V, n)
+	size := (len(v) + n - 1) / n
+
+	var wg sync.WaitGroup
+	for i := range chunks {
+		lo, hi := min(i*size, len(v)), min((i+1)*size, len(v))
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			chunks[i] = filterValues(v[lo:hi], keep)
+		}()
+	}
+	wg.Wait()
+
+	v = v[:0]
+	for _, c := range chunks {
+		v = append(v, c...)
+	}
+
+	return v
+}
+
+// filterValues filters `v` in place, keeping the values satisfying `keep`.
+func filterValues[V comparable](v []V, keep func(V) bool) []V {
+	w := v[:0]
+	for _, k := range v {
+		if keep(k) {
+			w = append(w, k)
+		}
+	}
+
+	return w
+}
@@ -0,0 +1,117 @@
+package set_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestParallelDifference(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.Set[int]
+		t    *set.Set[int]
+		want *set.Set[int]
+	}{
+		{
+			name: "small",
+			s:    set.New(1, 2, 3),
+			t:    set.New(1, 2, 4, 5),
+			want: set.New(3),
+		},
+		{
+			name: "large",
+			s:    rangeSet(0, 4*set.ParallelThreshold),
+			t:    rangeSet(set.ParallelThreshold, 5*set.ParallelThreshold),
+			want: rangeSet(0, set.ParallelThreshold),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, set.ParallelDifference(tt.s, tt.t), cmp.Comparer(equal(t))); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParallelIntersection(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.Set[int]
+		t    *set.Set[int]
+		want *set.Set[int]
+	}{
+		{
+			name: "small",
+			s:    set.New(1, 2, 3),
+			t:    set.New(2, 3, 5),
+			want: set.New(2, 3),
+		},
+		{
+			name: "large",
+			s:    rangeSet(0, 4*set.ParallelThreshold),
+			t:    rangeSet(set.ParallelThreshold, 6*set.ParallelThreshold),
+			want: rangeSet(set.ParallelThreshold, 4*set.ParallelThreshold),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, set.ParallelIntersection(tt.s, tt.t), cmp.Comparer(equal(t))); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParallelUnion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.Set[int]
+		t    *set.Set[int]
+		want *set.Set[int]
+	}{
+		{
+			name: "small",
+			s:    set.New(1, 2),
+			t:    set.New(2, 3),
+			want: set.New(1, 2, 3),
+		},
+		{
+			name: "large",
+			s:    rangeSet(0, 2*set.ParallelThreshold),
+			t:    rangeSet(set.ParallelThreshold, 4*set.ParallelThreshold),
+			want: rangeSet(0, 4*set.ParallelThreshold),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, set.ParallelUnion(tt.s, tt.t), cmp.Comparer(equal(t))); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package set
+
+import (
+	"iter"
+	"slices"
+)
+
+// Patch describes the changes turning one Set into another.
+type Patch[V comparable] struct {
+	// Added holds the values to insert.
+	Added *Set[V]
+	// Removed holds the values to delete.
+	Removed *Set[V]
+}
+
+// Diff returns the Patch turning `old` into `new`.
+//
+// For example:
+//
+//	old = {a1, a2}
+//	new = {a2, a3}
+//	set.Diff(old, new) = {Added: {a3}, Removed: {a1}}
+func Diff[V comparable](old, new *Set[V]) Patch[V] {
+	return Patch[V]{
+		Added:   new.Difference(old),
+		Removed: old.Difference(new),
+	}
+}
+
+// ApplyPatch deletes the values of `p.Removed` from `s` and then inserts the
+// values of `p.Added`, so that a value in both ends up in `s`. Either set of
+// `p` may be nil.
+//
+// The patch is applied under a single lock, so other goroutines see `s` either
+// before or after the whole patch.
+func (s *Set[V]) ApplyPatch(p Patch[V]) {
+	// The values are copied first, since the sets of `p` may be `s` itself
+	// or be locked by a call waiting for `s`.
+	var removed, added []V
+	if p.Removed != nil {
+		removed = p.Removed.Values()
+	}
+	if p.Added != nil {
+		added = p.Added.Values()
+	}
+
+	defer s.unlock(s.lock("ApplyPatch"))
+
+	s.applyPatch(slices.Values(removed), slices.Values(added))
+}
+
+// applyPatch deletes the values of `removed` from `s` and then inserts the
+// values of `added`, as a single step of its history.
+func (s *Set[V]) applyPatch(removed, added iter.Seq[V]) {
+	s.beginStep()
+	defer s.endStep()
+
+	for k := range removed {
+		s.delete(k)
+	}
+	for k := range added {
+		s.insert(k)
+	}
+}
@@ -0,0 +1,89 @@
+package set_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		old  *set.Set[int]
+		new  *set.Set[int]
+		want set.Patch[int]
+	}{
+		{
+			name: "added and removed",
+			old:  set.New(1, 2),
+			new:  set.New(2, 3),
+			want: set.Patch[int]{Added: set.New(3), Removed: set.New(1)},
+		},
+		{
+			name: "equal",
+			old:  set.New(1, 2),
+			new:  set.New(1, 2),
+			want: set.Patch[int]{Added: set.New[int](), Removed: set.New[int]()},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := set.Diff(tt.old, tt.new)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+
+			tt.old.ApplyPatch(got)
+			if diff := cmp.Diff(tt.new, tt.old); diff != "" {
+				t.Errorf("applied (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetApplyPatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.Set[int]
+		p    set.Patch[int]
+		want *set.Set[int]
+	}{
+		{
+			name: "nil sets",
+			s:    set.New(1),
+			p:    set.Patch[int]{},
+			want: set.New(1),
+		},
+		{
+			name: "added and removed",
+			s:    set.New(1, 2),
+			p:    set.Patch[int]{Added: set.New(2, 3), Removed: set.New(1, 2)},
+			want: set.New(2, 3),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.s.ApplyPatch(tt.p)
+
+			if diff := cmp.Diff(tt.want, tt.s); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package set
+
+import (
+	"bufio"
+	"bytes"
+	"encoding"
+	"fmt"
+	"io"
+)
+
+// WriteTo implements io.WriterTo. It writes the values of `s` to `w` as text,
+// each followed by a newline, without building the whole output in memory.
+//
+// Values must be strings or implement encoding.TextMarshaler, and their text
+// must not contain newlines. See Encode for other types.
+func (s *Set[V]) WriteTo(w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+
+	var (
+		n   int64
+		buf []byte
+	)
+
+	for k := range s.All() {
+		var err error
+		if buf, err = appendText(buf[:0], k); err != nil {
+			return n, err
+		}
+		if bytes.IndexByte(buf, '\n') >= 0 {
+			return n, fmt.Errorf("set: value %q contains a newline", buf)
+		}
+
+		m, err := bw.Write(append(buf, '\n'))
+		n += int64(m)
+		if err != nil {
+			return n, err
+		}
+	}
+
+	return n, bw.Flush()
+}
+
+// ReadFrom implements io.ReaderFrom. It inserts into `s` the values read from
+// `r` in the format written by WriteTo, until the end of `r`.
+//
+// Values must be strings or implement encoding.TextUnmarshaler through their
+// pointer. They are inserted in batches, so that `s` is not locked while
+// waiting for `r`.
+func (s *Set[V]) ReadFrom(r io.Reader) (int64, error) {
+	br := bufio.NewReader(r)
+
+	var n int64
+
+	err := s.insertAll(func() (v V, err error) {
+		line, err := br.ReadBytes('\n')
+		n += int64(len(line))
+
+		if err == io.EOF && len(line) > 0 {
+			err = nil
+		}
+		if err != nil {
+			return v, err
+		}
+
+		return unmarshalText[V](bytes.TrimSuffix(line, []byte("\n")))
+	})
+
+	return n, err
+}
+
+// Encode writes the values of `s` encoded by `c` to `w`, each prefixed by its
+// length as a uvarint, and returns the number of bytes written.
+//
+// Unlike WriteTo, Encode supports any type of value and values containing
+// newlines.
+func Encode[V comparable](w io.Writer, s *Set[V], c Codec[V]) (int64, error) {
+	bw := bufio.NewWriter(w)
+
+	var (
+		n   int64
+		buf []byte
+	)
+
+	for k := range s.All() {
+		buf = c.Append(buf[:0], k)
+
+		m, err := writeRecord(bw, buf)
+		n += int64(m)
+		if err != nil {
+			return n, err
+		}
+	}
+
+	return n, bw.Flush()
+}
+
+// Decode inserts into `s` the values read from `r` in the format written by
+// Encode, until the end of `r`, and returns the number of bytes read. As with
+// ReadFrom, the values are inserted in batches.
+func Decode[V comparable](r io.Reader, s *Set[V], c Codec[V]) (int64, error) {
+	br := bufio.NewReader(r)
+
+	var n int64
+
+	err := s.insertAll(func() (v V, err error) {
+		b, m, err := readRecord(br)
+		n += int64(m)
+		if err != nil {
+			return v, err
+		}
+
+		return c.Decode(b)
+	})
+
+	return n, err
+}
+
+// readBatch is the number of values read by ReadFrom and Decode before they
+// are inserted.
+const readBatch = 1024
+
+// insertAll inserts into `s` the values returned by `next` until it returns
+// an error, which is returned unless it is io.EOF.
+//
+// The values are inserted by batches of readBatch, each under a single lock
+// and as a single step of the history, so that `s` is not locked while `next`
+// reads its input.
+func (s *Set[V]) insertAll(next func() (V, error)) error {
+	batch := make([]V, 0, readBatch)
+
+	flush := func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.beginStep()
+		defer s.endStep()
+
+		for _, v := range batch {
+			s.insert(v)
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		v, err := next()
+		if err != nil {
+			flush()
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		if batch = append(batch, v); len(batch) == readBatch {
+			flush()
+		}
+	}
+}
+
+// appendText appends the text of `v` to `dst`.
+func appendText[V any](dst []byte, v V) ([]byte, error) {
+	switch x := any(v).(type) {
+	case string:
+		return append(dst, x...), nil
+	case encoding.TextAppender:
+		return x.AppendText(dst)
+	case encoding.TextMarshaler:
+		b, err := x.MarshalText()
+		return append(dst, b...), err
+	default:
+		return dst, fmt.Errorf("set: %T is neither a string nor an encoding.TextMarshaler", v)
+	}
+}
+
+// unmarshalText returns the value whose text is `b`.
+func unmarshalText[V any](b []byte) (V, error) {
+	var v V
+
+	switch p := any(&v).(type) {
+	case *string:
+		*p = string(b)
+	case encoding.TextUnmarshaler:
+		if err := p.UnmarshalText(b); err != nil {
+			return v, err
+		}
+	default:
+		return v, fmt.Errorf("set: %T is neither a string nor an encoding.TextUnmarshaler", v)
+	}
+
+	return v, nil
+}
@@ -0,0 +1,163 @@
+package set_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"net/netip"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestSetWriteTo(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		s       *set.Set[string]
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			s:    set.New[string](),
+		},
+		{
+			name: "strings",
+			s:    set.New("a", "", "long "+strings.Repeat("x", 10000)),
+		},
+		{
+			name: "several batches",
+			s:    set.Collect(rangeSet(0, 3000).Values(), strconv.Itoa),
+		},
+		{
+			name:    "newline",
+			s:       set.New("a\nb"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+
+			n, err := tt.s.WriteTo(&buf)
+			if diff := cmp.Diff(tt.wantErr, err != nil); diff != "" {
+				t.Fatalf("error %v (-want +got):\n%s", err, diff)
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := cmp.Diff(int64(buf.Len()), n); diff != "" {
+				t.Errorf("written (-want +got):\n%s", diff)
+			}
+
+			got := set.New[string]()
+			if _, err := got.ReadFrom(&buf); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.s, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetReadFrom(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		in      string
+		want    *set.Set[netip.Addr]
+		wantErr bool
+	}{
+		{
+			name: "text unmarshaler",
+			in:   "10.0.0.1\n::1\n10.0.0.1\n",
+			want: set.New(netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("::1")),
+		},
+		{
+			name: "no final newline",
+			in:   "10.0.0.1\n10.0.0.2",
+			want: set.New(netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("10.0.0.2")),
+		},
+		{
+			name:    "invalid",
+			in:      "10.0.0.1\nnot an address\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := set.New[netip.Addr]()
+
+			n, err := got.ReadFrom(strings.NewReader(tt.in))
+			if diff := cmp.Diff(tt.wantErr, err != nil); diff != "" {
+				t.Fatalf("error %v (-want +got):\n%s", err, diff)
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := cmp.Diff(int64(len(tt.in)), n); diff != "" {
+				t.Errorf("read (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	t.Parallel()
+
+	s := set.New("a", "b\nc", "")
+
+	var buf bytes.Buffer
+
+	n, err := set.Encode(&buf, s, set.StringCodec{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(int64(buf.Len()), n); diff != "" {
+		t.Errorf("written (-want +got):\n%s", diff)
+	}
+
+	size := int64(buf.Len())
+	got := set.New[string]()
+
+	n, err = set.Decode(&buf, got, set.StringCodec{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(size, n); diff != "" {
+		t.Errorf("read (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(s, got); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+
+	for _, in := range []string{
+		"\x05ab",
+		string(binary.AppendUvarint(nil, 1<<62)) + "ab",
+		string(binary.AppendUvarint(nil, math.MaxUint64)),
+	} {
+		if _, err := set.Decode(strings.NewReader(in), got, set.StringCodec{}); err == nil {
+			t.Errorf("got no error decoding the truncated record %q", in)
+		}
+	}
+}
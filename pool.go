@@ -0,0 +1,89 @@
+package set
+
+import (
+	"sync"
+)
+
+// Reset removes all the values from `s` while keeping its storage, so that `s`
+// can be reused for a similar number of values without allocating.
+//
+// Reset calls the OnDelete hooks for every value, as Delete would. Options
+// set on `s` are kept; see Pool to reuse sets across unrelated callers.
+func (s *Set[V]) Reset() {
+	defer s.unlock(s.lock("Reset"))
+
+	s.reset()
+}
+
+// reset removes all the values from `s`.
+func (s *Set[V]) reset() {
+	s.beginStep()
+	defer s.endStep()
+
+	if s.size() == 0 {
+		return
+	}
+
+	iterating := s.iterating.Load() > 0
+
+	if s.cfg != nil && (s.cfg.metrics != nil || len(s.cfg.onDelete) > 0 || s.cfg.history != nil) {
+		if s.m == nil && !iterating {
+			// Deleting the last value of the slice neither reorders nor
+			// copies it.
+			for len(s.small) > 0 {
+				s.delete(s.small[len(s.small)-1])
+			}
+			return
+		}
+
+		for k := range s.values() {
+			s.delete(k)
+		}
+		return
+	}
+
+	switch {
+	case s.m != nil:
+		clear(s.m)
+	case iterating:
+		// Iterations in progress range over the current backing array,
+		// which must not be cleared under them.
+		s.small = nil
+	default:
+		clear(s.small)
+		s.small = s.small[:0]
+	}
+
+	s.version++
+}
+
+// Pool is a pool of empty sets, which lets short-lived sets reuse the storage
+// of sets released earlier rather than generating garbage.
+//
+// The zero value is an empty pool ready to use. A Pool is safe for concurrent
+// use, but the sets it returns are not shared.
+type Pool[V comparable] struct {
+	p sync.Pool
+}
+
+// Get returns an empty Set from `p`, or a new one if `p` is empty.
+func (p *Pool[V]) Get() *Set[V] {
+	if s, ok := p.p.Get().(*Set[V]); ok {
+		return s
+	}
+
+	return New[V]()
+}
+
+// Put resets `s` and adds it to `p`. Options set on `s`, such as hooks, are
+// dropped, so that they do not carry over to the next caller of Get.
+//
+// `s` must not be used after Put.
+func (p *Pool[V]) Put(s *Set[V]) {
+	s.mu.Lock()
+	s.cfg = nil
+	s.reset()
+	s.mu.Unlock()
+
+	p.p.Put(s)
+}
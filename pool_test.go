@@ -0,0 +1,108 @@
+package set_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestSetReset(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.Set[int]
+	}{
+		{
+			name: "empty",
+			s:    set.New[int](),
+		},
+		{
+			name: "small",
+			s:    rangeSet(0, 3),
+		},
+		{
+			name: "large",
+			s:    rangeSet(0, 1000),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var deleted []int
+			tt.s.OnDelete(func(v int) { deleted = append(deleted, v) })
+
+			want := sortedValues(tt.s)
+			before := tt.s.SizeBytes()
+
+			tt.s.Reset()
+
+			if diff := cmp.Diff(0, tt.s.Len()); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(len(want), len(deleted)); diff != "" {
+				t.Errorf("deleted (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(before, tt.s.SizeBytes()); diff != "" {
+				t.Errorf("size (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPool(t *testing.T) {
+	t.Parallel()
+
+	var p set.Pool[int]
+
+	var inserted int
+
+	s := p.Get()
+	s.OnInsert(func(int) { inserted++ })
+	s.Insert(1, 2, 3)
+	p.Put(s)
+
+	s = p.Get()
+	if diff := cmp.Diff(0, s.Len()); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+
+	s.Insert(4)
+	if diff := cmp.Diff(set.New(4), s); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(3, inserted); diff != "" {
+		t.Errorf("hook carried over (-want +got):\n%s", diff)
+	}
+}
+
+func BenchmarkPool(b *testing.B) {
+	labels := []string{"method", "path", "status", "region"}
+
+	b.Run("New", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			s := set.New[string]()
+			s.Insert(labels...)
+			_ = s.Contains("status")
+		}
+	})
+
+	b.Run("Pool", func(b *testing.B) {
+		var p set.Pool[string]
+
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			s := p.Get()
+			s.Insert(labels...)
+			_ = s.Contains("status")
+			p.Put(s)
+		}
+	})
+}
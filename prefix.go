@@ -0,0 +1,268 @@
+package set
+
+import (
+	"fmt"
+	"iter"
+	"sort"
+)
+
+// PrefixSet is a set of strings backed by a trie, supporting prefix queries.
+//
+// Values are iterated in lexicographic byte order. Unlike Set, a PrefixSet is
+// not safe for concurrent use.
+type PrefixSet struct {
+	root trieNode
+	size int
+}
+
+type trieNode struct {
+	// children is sorted by label.
+	children []trieEdge
+	end      bool
+}
+
+type trieEdge struct {
+	label byte
+	node  *trieNode
+}
+
+// NewPrefixSet returns a PrefixSet from the given values.
+func NewPrefixSet(v ...string) *PrefixSet {
+	s := &PrefixSet{}
+
+	s.Insert(v...)
+
+	return s
+}
+
+// Clone returns a new PrefixSet that is a copy of `s`.
+func (s *PrefixSet) Clone() *PrefixSet {
+	t := NewPrefixSet()
+
+	for v := range s.All() {
+		t.Insert(v)
+	}
+
+	return t
+}
+
+// All returns an iterator over the values of `s`.
+func (s *PrefixSet) All() iter.Seq[string] {
+	return s.WithPrefix("")
+}
+
+// Contains returns true iff `s` contains a given value.
+func (s *PrefixSet) Contains(v string) bool {
+	n := s.root.find(v)
+	return n != nil && n.end
+}
+
+// Delete removes the given values from `s`.
+func (s *PrefixSet) Delete(v ...string) {
+	for _, x := range v {
+		if s.root.delete(x) {
+			s.size--
+		}
+	}
+}
+
+// Difference returns a new PrefixSet whose values are in `s` and not in `t`.
+func (s *PrefixSet) Difference(t *PrefixSet) *PrefixSet {
+	u := NewPrefixSet()
+
+	for v := range s.All() {
+		if !t.Contains(v) {
+			u.Insert(v)
+		}
+	}
+
+	return u
+}
+
+// Equal returns true iff `s` is equal to `t`.
+func (s *PrefixSet) Equal(t *PrefixSet) bool {
+	if s.size != t.size {
+		return false
+	}
+
+	for v := range s.All() {
+		if !t.Contains(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// HasPrefix returns true iff `s` contains a value starting with `p`.
+func (s *PrefixSet) HasPrefix(p string) bool {
+	n := s.root.find(p)
+	return n != nil && (n.end || len(n.children) > 0)
+}
+
+// Insert adds the given values to `s`.
+func (s *PrefixSet) Insert(v ...string) {
+	for _, x := range v {
+		n := &s.root
+		for i := 0; i < len(x); i++ {
+			n = n.child(x[i], true)
+		}
+
+		if !n.end {
+			n.end = true
+			s.size++
+		}
+	}
+}
+
+// Intersection returns a new PrefixSet whose values are included in both `s`
+// and `t`.
+func (s *PrefixSet) Intersection(t *PrefixSet) *PrefixSet {
+	walk, other := s, t
+	if t.size < s.size {
+		walk, other = t, s
+	}
+
+	u := NewPrefixSet()
+
+	for v := range walk.All() {
+		if other.Contains(v) {
+			u.Insert(v)
+		}
+	}
+
+	return u
+}
+
+// Len returns the size of `s`.
+func (s *PrefixSet) Len() int {
+	return s.size
+}
+
+// LongestPrefix returns the longest value of `s` that is a prefix of `v`, and
+// false if there is none.
+//
+// For example:
+//
+//	s = {"/", "/api", "/api/v1"}
+//	s.LongestPrefix("/api/v2/users") = "/api", true
+func (s *PrefixSet) LongestPrefix(v string) (string, bool) {
+	n := &s.root
+
+	longest, ok := 0, n.end
+	for i := 0; i < len(v); i++ {
+		if n = n.child(v[i], false); n == nil {
+			break
+		}
+		if n.end {
+			longest, ok = i+1, true
+		}
+	}
+
+	return v[:longest], ok
+}
+
+// String implements fmt.Stringer.
+func (s *PrefixSet) String() string {
+	return fmt.Sprint(s.Values())
+}
+
+// Union returns a new PrefixSet whose values are included in either `s` or `t`.
+func (s *PrefixSet) Union(t *PrefixSet) *PrefixSet {
+	u := s.Clone()
+
+	for v := range t.All() {
+		u.Insert(v)
+	}
+
+	return u
+}
+
+// Values returns the values of `s` as a slice in lexicographic order.
+func (s *PrefixSet) Values() []string {
+	v := make([]string, 0, s.size)
+
+	for x := range s.All() {
+		v = append(v, x)
+	}
+
+	return v
+}
+
+// WithPrefix returns an iterator over the values of `s` starting with `p`, in
+// lexicographic order.
+func (s *PrefixSet) WithPrefix(p string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		n := s.root.find(p)
+		if n == nil {
+			return
+		}
+
+		n.walk([]byte(p), yield)
+	}
+}
+
+// child returns the child of `n` labeled `b`, creating it if `create` is true.
+func (n *trieNode) child(b byte, create bool) *trieNode {
+	i := sort.Search(len(n.children), func(i int) bool { return n.children[i].label >= b })
+	if i < len(n.children) && n.children[i].label == b {
+		return n.children[i].node
+	}
+	if !create {
+		return nil
+	}
+
+	c := &trieNode{}
+	n.children = append(n.children, trieEdge{})
+	copy(n.children[i+1:], n.children[i:])
+	n.children[i] = trieEdge{b, c}
+
+	return c
+}
+
+// find returns the node reached by following `p` from `n`, or nil.
+func (n *trieNode) find(p string) *trieNode {
+	for i := 0; i < len(p) && n != nil; i++ {
+		n = n.child(p[i], false)
+	}
+
+	return n
+}
+
+// delete removes `v` below `n`, pruning nodes left without values. It returns
+// true iff `v` was found.
+func (n *trieNode) delete(v string) bool {
+	if v == "" {
+		ok := n.end
+		n.end = false
+		return ok
+	}
+
+	c := n.child(v[0], false)
+	if c == nil || !c.delete(v[1:]) {
+		return false
+	}
+
+	if !c.end && len(c.children) == 0 {
+		i := sort.Search(len(n.children), func(i int) bool { return n.children[i].label >= v[0] })
+		n.children = append(n.children[:i], n.children[i+1:]...)
+	}
+
+	return true
+}
+
+// walk yields the values below `n` prefixed with `buf`. It returns false if
+// `yield` stopped the iteration.
+func (n *trieNode) walk(buf []byte, yield func(string) bool) bool {
+	if n.end && !yield(string(buf)) {
+		return false
+	}
+
+	for _, e := range n.children {
+		if !e.node.walk(append(buf, e.label), yield) {
+			return false
+		}
+	}
+
+	return true
+}
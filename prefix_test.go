@@ -0,0 +1,316 @@
+package set_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestPrefixSetDelete(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.PrefixSet
+		v    []string
+		want []string
+	}{
+		{
+			name: "delete leaf",
+			s:    set.NewPrefixSet("a", "ab", "abc"),
+			v:    []string{"abc"},
+			want: []string{"a", "ab"},
+		},
+		{
+			name: "delete inner",
+			s:    set.NewPrefixSet("a", "ab", "abc"),
+			v:    []string{"ab", "x"},
+			want: []string{"a", "abc"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.s.Delete(tt.v...)
+
+			if diff := cmp.Diff(tt.want, tt.s.Values()); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(len(tt.want), tt.s.Len()); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPrefixSetContains(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.PrefixSet
+		v    string
+		want bool
+	}{
+		{
+			name: "contains",
+			s:    set.NewPrefixSet("foo", "foobar"),
+			v:    "foo",
+			want: true,
+		},
+		{
+			name: "not contains prefix",
+			s:    set.NewPrefixSet("foobar"),
+			v:    "foo",
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, tt.s.Contains(tt.v)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPrefixSetHasPrefix(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.PrefixSet
+		p    string
+		want bool
+	}{
+		{
+			name: "has prefix",
+			s:    set.NewPrefixSet("/api/v1"),
+			p:    "/api",
+			want: true,
+		},
+		{
+			name: "not has prefix",
+			s:    set.NewPrefixSet("/api/v1"),
+			p:    "/apx",
+			want: false,
+		},
+		{
+			name: "empty prefix of empty set",
+			s:    set.NewPrefixSet(),
+			p:    "",
+			want: false,
+		},
+		{
+			name: "deleted value",
+			s: func() *set.PrefixSet {
+				s := set.NewPrefixSet("/api/v1")
+				s.Delete("/api/v1")
+				return s
+			}(),
+			p:    "/api",
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, tt.s.HasPrefix(tt.p)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPrefixSetWithPrefix(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.PrefixSet
+		p    string
+		want []string
+	}{
+		{
+			name: "with prefix",
+			s:    set.NewPrefixSet("/api/v2", "/api/v1", "/api", "/docs"),
+			p:    "/api/",
+			want: []string{"/api/v1", "/api/v2"},
+		},
+		{
+			name: "no match",
+			s:    set.NewPrefixSet("/api"),
+			p:    "/docs",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, slices.Collect(tt.s.WithPrefix(tt.p))); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPrefixSetLongestPrefix(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		s      *set.PrefixSet
+		v      string
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "longest prefix",
+			s:      set.NewPrefixSet("/", "/api", "/api/v1"),
+			v:      "/api/v2/users",
+			want:   "/api",
+			wantOK: true,
+		},
+		{
+			name:   "exact match",
+			s:      set.NewPrefixSet("/", "/api"),
+			v:      "/api",
+			want:   "/api",
+			wantOK: true,
+		},
+		{
+			name:   "no prefix",
+			s:      set.NewPrefixSet("/api"),
+			v:      "/docs",
+			want:   "",
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := tt.s.LongestPrefix(tt.v)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantOK, ok); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPrefixSetUnion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.PrefixSet
+		t    *set.PrefixSet
+		want *set.PrefixSet
+	}{
+		{
+			name: "union",
+			s:    set.NewPrefixSet("a", "b"),
+			t:    set.NewPrefixSet("b", "c"),
+			want: set.NewPrefixSet("a", "b", "c"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, tt.s.Union(tt.t)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPrefixSetIntersection(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.PrefixSet
+		t    *set.PrefixSet
+		want *set.PrefixSet
+	}{
+		{
+			name: "intersection",
+			s:    set.NewPrefixSet("a", "ab", "b"),
+			t:    set.NewPrefixSet("ab", "b", "c"),
+			want: set.NewPrefixSet("ab", "b"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, tt.s.Intersection(tt.t)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPrefixSetDifference(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.PrefixSet
+		t    *set.PrefixSet
+		want *set.PrefixSet
+	}{
+		{
+			name: "difference",
+			s:    set.NewPrefixSet("a", "ab", "b"),
+			t:    set.NewPrefixSet("ab"),
+			want: set.NewPrefixSet("a", "b"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, tt.s.Difference(tt.t)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
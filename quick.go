@@ -0,0 +1,168 @@
+package set
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"reflect"
+)
+
+// Generate implements testing/quick.Generator, so that quick.Check can pass
+// random sets to property tests. The set holds up to `size` values of `V`,
+// which are generated like testing/quick generates values: `V` may implement
+// quick.Generator itself, or be made of booleans, numbers, strings, arrays,
+// structs with exported fields and pointers.
+//
+// For example, to check that Union is commutative:
+//
+//	f := func(s, t *set.Set[int]) bool {
+//		return s.Union(t).Equal(t.Union(s))
+//	}
+//	err := quick.Check(f, nil)
+//
+// Floats are never NaN, which is not equal to itself. Generate panics if
+// values of `V` cannot be generated.
+func (*Set[V]) Generate(r *rand.Rand, size int) reflect.Value {
+	s := New[V]()
+
+	for n := r.Intn(size + 1); n > 0; n-- {
+		s.insert(arbitrary[V](r, size))
+	}
+
+	return reflect.ValueOf(s)
+}
+
+// FromFuzz returns a Set of values of `V` derived from `data`, so that native
+// fuzz tests can take sets as arguments. The same `data` always gives the same
+// set, and the values are generated as by Generate from the bytes of `data`
+// until they run out.
+//
+// For example:
+//
+//	f.Fuzz(func(t *testing.T, a, b []byte) {
+//		s, u := set.FromFuzz[string](a), set.FromFuzz[string](b)
+//		// Check properties of s and u.
+//	})
+func FromFuzz[V comparable](data []byte) *Set[V] {
+	src := &fuzzSource{data: data}
+	r := rand.New(src)
+
+	s := New[V]()
+
+	// Values of a type such as struct{} are generated from no data at all,
+	// so the number of values is bounded as well.
+	for range len(data) {
+		if len(src.data) == 0 {
+			break
+		}
+		s.insert(arbitrary[V](r, len(data)))
+	}
+
+	return s
+}
+
+// generator is testing/quick.Generator, which is not imported since its
+// package registers command-line flags.
+type generator interface {
+	Generate(r *rand.Rand, size int) reflect.Value
+}
+
+// arbitrary returns a random value of `V`, panicking if it cannot be
+// generated.
+func arbitrary[V any](r *rand.Rand, size int) V {
+	t := reflect.TypeFor[V]()
+
+	v, ok := arbitraryValue(t, r, size)
+	if !ok {
+		panic(fmt.Sprintf("set: cannot generate values of type %v", t))
+	}
+
+	return v.Interface().(V)
+}
+
+// arbitraryValue returns a random value of type `t`, and false if values of
+// `t` cannot be generated.
+func arbitraryValue(t reflect.Type, r *rand.Rand, size int) (reflect.Value, bool) {
+	if g, ok := reflect.Zero(t).Interface().(generator); ok {
+		return g.Generate(r, size), true
+	}
+
+	v := reflect.New(t).Elem()
+
+	switch t.Kind() {
+	case reflect.Bool:
+		v.SetBool(r.Int()&1 == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(r.Uint64()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(r.Uint64())
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(r.NormFloat64() * float64(size))
+	case reflect.Complex64, reflect.Complex128:
+		v.SetComplex(complex(r.NormFloat64()*float64(size), r.NormFloat64()*float64(size)))
+	case reflect.String:
+		runes := make([]rune, r.Intn(size+1))
+		for i := range runes {
+			runes[i] = rune(r.Intn(0x10ffff))
+		}
+		v.SetString(string(runes))
+	case reflect.Array:
+		for i := range v.Len() {
+			e, ok := arbitraryValue(t.Elem(), r, size)
+			if !ok {
+				return v, false
+			}
+			v.Index(i).Set(e)
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if !t.Field(i).IsExported() {
+				return v, false
+			}
+
+			f, ok := arbitraryValue(t.Field(i).Type, r, size)
+			if !ok {
+				return v, false
+			}
+			v.Field(i).Set(f)
+		}
+	case reflect.Pointer:
+		if r.Intn(size+1) == 0 {
+			return v, true
+		}
+
+		e, ok := arbitraryValue(t.Elem(), r, size)
+		if !ok {
+			return v, false
+		}
+		p := reflect.New(t.Elem())
+		p.Elem().Set(e)
+		v.Set(p)
+	default:
+		return v, false
+	}
+
+	return v, true
+}
+
+// fuzzSource is a rand.Source reading its numbers from fuzzing data, and then
+// returning zeros once the data runs out.
+type fuzzSource struct {
+	data []byte
+}
+
+func (s *fuzzSource) Uint64() uint64 {
+	var b [8]byte
+	n := copy(b[:], s.data)
+	s.data = s.data[n:]
+
+	// Numbers are read big-endian so that the first bytes decide small
+	// numbers, which are drawn from the high bits.
+	return binary.BigEndian.Uint64(b[:])
+}
+
+func (s *fuzzSource) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+func (*fuzzSource) Seed(int64) {}
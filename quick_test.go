@@ -0,0 +1,135 @@
+package set_test
+
+import (
+	"testing"
+	"testing/quick"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestSetGenerate(t *testing.T) {
+	t.Parallel()
+
+	type point struct {
+		X, Y int8
+		Name string
+	}
+
+	tests := []struct {
+		name string
+		f    any
+	}{
+		{
+			name: "union is commutative",
+			f: func(s, t *set.Set[int]) bool {
+				return s.Union(t).Equal(t.Union(s))
+			},
+		},
+		{
+			name: "intersection distributes over union",
+			f: func(s, t, u *set.Set[string]) bool {
+				return s.Intersection(t.Union(u)).Equal(s.Intersection(t).Union(s.Intersection(u)))
+			},
+		},
+		{
+			name: "de morgan",
+			f: func(s, t, u *set.Set[point]) bool {
+				return s.Difference(t.Union(u)).Equal(s.Difference(t).Intersection(s.Difference(u)))
+			},
+		},
+		{
+			name: "floats are equal to themselves",
+			f: func(s *set.Set[float64]) bool {
+				return s.Equal(s.Clone())
+			},
+		},
+		{
+			name: "sizes are bounded",
+			f: func(s *set.Set[[2]bool]) bool {
+				return s.Len() <= 4
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := quick.Check(tt.f, nil); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestSetGeneratePanic(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("got no panic generating a Set[any]")
+		}
+	}()
+
+	quick.Check(func(*set.Set[any]) bool { return true }, nil)
+}
+
+func TestFromFuzz(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data []byte
+		want *set.Set[uint16]
+	}{
+		{
+			name: "empty",
+			data: nil,
+			want: set.New[uint16](),
+		},
+		{
+			name: "values",
+			data: []byte{0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 1},
+			want: set.New[uint16](1, 2),
+		},
+		{
+			name: "partial value",
+			data: []byte{0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 1},
+			want: set.New[uint16](1, 256),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, set.FromFuzz[uint16](tt.data), cmp.Comparer(func(s, t *set.Set[uint16]) bool {
+				return s.Equal(t)
+			})); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func FuzzFromFuzz(f *testing.F) {
+	f.Add([]byte("foo"), []byte("bar"))
+	f.Add([]byte{}, []byte("baz"))
+
+	f.Fuzz(func(t *testing.T, a, b []byte) {
+		s, u := set.FromFuzz[string](a), set.FromFuzz[string](b)
+
+		if !s.Equal(set.FromFuzz[string](a)) {
+			t.Error("got different sets from the same data")
+		}
+		if got, want := s.Union(u).Len(), s.Len()+u.Len()-s.Intersection(u).Len(); got != want {
+			t.Errorf("got a union of %d values, want %d", got, want)
+		}
+	})
+}
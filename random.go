@@ -0,0 +1,59 @@
+package set
+
+import (
+	"cmp"
+	"hash/maphash"
+	"iter"
+	"math/rand/v2"
+	"slices"
+)
+
+// Any returns a value chosen uniformly at random from `s` without removing it,
+// and false if `s` is empty.
+func (s *Set[V]) Any() (v V, _ bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.size() == 0 {
+		return v, false
+	}
+	if s.m == nil {
+		return s.small[rand.IntN(len(s.small))], true
+	}
+
+	i := rand.IntN(len(s.m))
+	for k := range s.m {
+		if i == 0 {
+			return k, true
+		}
+		i--
+	}
+
+	return v, false
+}
+
+// Shuffled returns an iterator over the values of `s` in a uniformly random
+// order drawn from `r`.
+//
+// The values are put in a canonical order before being shuffled, so that within
+// a process the same seed of `r` yields the same order for equal sets. With the
+// setdeterministic build tag, this also holds across processes.
+func (s *Set[V]) Shuffled(r *rand.Rand) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		v := s.Values()
+		if !deterministic {
+			slices.SortFunc(v, func(a, b V) int {
+				return cmp.Compare(maphash.Comparable(hashSeed, a), maphash.Comparable(hashSeed, b))
+			})
+		}
+
+		for i := range v {
+			j := i + r.IntN(len(v)-i)
+			v[i], v[j] = v[j], v[i]
+
+			if !yield(v[i]) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package set_test
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestSetAny(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		s        *set.Set[int]
+		wantBool bool
+		wantLen  int
+	}{
+		{
+			name:     "any",
+			s:        set.New(1, 2, 3),
+			wantBool: true,
+			wantLen:  3,
+		},
+		{
+			name:     "empty",
+			s:        set.New[int](),
+			wantBool: false,
+			wantLen:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := tt.s.Any()
+
+			if diff := cmp.Diff(tt.wantBool, ok); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+			if ok && !tt.s.Contains(got) {
+				t.Errorf("Any returned %d, which is not in %v", got, tt.s)
+			}
+			if diff := cmp.Diff(tt.wantLen, tt.s.Len()); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetAnyUniform(t *testing.T) {
+	t.Parallel()
+
+	const n = 30000
+
+	s := set.New(0, 1, 2)
+	counts := make([]int, s.Len())
+	for i := 0; i < n; i++ {
+		v, _ := s.Any()
+		counts[v]++
+	}
+
+	for v, c := range counts {
+		if got := float64(c) / n; got < 0.3 || got > 0.37 {
+			t.Errorf("value %d chosen with frequency %v", v, got)
+		}
+	}
+}
+
+func TestSetShuffled(t *testing.T) {
+	t.Parallel()
+
+	s := rangeSet(0, 100)
+
+	got := slices.Collect(s.Shuffled(rand.New(rand.NewPCG(1, 2))))
+
+	if diff := cmp.Diff(sortedValues(s), slices.Sorted(slices.Values(got))); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+
+	again := slices.Collect(rangeSet(0, 100).Shuffled(rand.New(rand.NewPCG(1, 2))))
+
+	if diff := cmp.Diff(got, again); diff != "" {
+		t.Errorf("same seed yielded different orders (-first +second):\n%s", diff)
+	}
+}
@@ -0,0 +1,108 @@
+package set
+
+import (
+	"container/heap"
+	"hash/maphash"
+)
+
+// Reservoir maintains a uniform random sample of up to k distinct values of a
+// stream, in memory proportional to k however long the stream is.
+//
+// It keeps the k values with the smallest hashes, so that a value occurring
+// many times in the stream is no more likely to be sampled than one occurring
+// once. Values are hashed with a seed chosen at process start, which lets
+// reservoirs of the same process be merged into the sample of the union of
+// their streams. Unlike Set, a Reservoir is not safe for concurrent use.
+type Reservoir[V comparable] struct {
+	k      int
+	values map[V]struct{}
+	heap   reservoirHeap[V]
+}
+
+type reservoirEntry[V comparable] struct {
+	hash  uint64
+	value V
+}
+
+// NewReservoir returns an empty Reservoir sampling up to `k` values.
+//
+// NewReservoir panics if `k` is less than 1.
+func NewReservoir[V comparable](k int) *Reservoir[V] {
+	if k < 1 {
+		panic("set: reservoir size must be at least 1")
+	}
+
+	return &Reservoir[V]{
+		k:      k,
+		values: make(map[V]struct{}, k),
+	}
+}
+
+// Add adds `v` to the stream sampled by `r`.
+func (r *Reservoir[V]) Add(v V) {
+	r.add(maphash.Comparable(hashSeed, v), v)
+}
+
+// Merge adds the values sampled by `u` to `r`, so that `r` samples the union
+// of the streams of both.
+func (r *Reservoir[V]) Merge(u *Reservoir[V]) {
+	for _, e := range u.heap {
+		r.add(e.hash, e.value)
+	}
+}
+
+// Sample returns a new Set of the sampled values, which are all the distinct
+// values of the stream if there are no more than k of them.
+func (r *Reservoir[V]) Sample() *Set[V] {
+	s := newSized[V](len(r.values))
+	for v := range r.values {
+		s.insert(v)
+	}
+
+	return s
+}
+
+// Len returns the number of sampled values.
+func (r *Reservoir[V]) Len() int {
+	return len(r.values)
+}
+
+// add adds `v`, whose hash is `h`, to the sample if it is one of the k values
+// with the smallest hashes.
+func (r *Reservoir[V]) add(h uint64, v V) {
+	if len(r.heap) == r.k && h >= r.heap[0].hash {
+		return
+	}
+	if _, ok := r.values[v]; ok {
+		return
+	}
+
+	r.values[v] = struct{}{}
+
+	if len(r.heap) < r.k {
+		heap.Push(&r.heap, reservoirEntry[V]{hash: h, value: v})
+		return
+	}
+
+	delete(r.values, r.heap[0].value)
+	r.heap[0] = reservoirEntry[V]{hash: h, value: v}
+	heap.Fix(&r.heap, 0)
+}
+
+// reservoirHeap is a max-heap of entries by hash.
+type reservoirHeap[V comparable] []reservoirEntry[V]
+
+func (h reservoirHeap[V]) Len() int           { return len(h) }
+func (h reservoirHeap[V]) Less(i, j int) bool { return h[i].hash > h[j].hash }
+func (h reservoirHeap[V]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *reservoirHeap[V]) Push(x any) {
+	*h = append(*h, x.(reservoirEntry[V]))
+}
+
+func (h *reservoirHeap[V]) Pop() any {
+	old := *h
+	e := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return e
+}
@@ -0,0 +1,140 @@
+package set_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestReservoir(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		k       int
+		stream  []int
+		wantLen int
+		want    *set.Set[int]
+	}{
+		{
+			name:    "empty",
+			k:       3,
+			wantLen: 0,
+			want:    set.New[int](),
+		},
+		{
+			name:    "fewer distinct values than k",
+			k:       3,
+			stream:  []int{1, 2, 1, 1, 2, 3, 3},
+			wantLen: 3,
+			want:    set.New(1, 2, 3),
+		},
+		{
+			name:    "more distinct values than k",
+			k:       10,
+			stream:  sortedValues(rangeSet(0, 1000)),
+			wantLen: 10,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := set.NewReservoir[int](tt.k)
+			for _, v := range tt.stream {
+				r.Add(v)
+			}
+
+			got := r.Sample()
+			if diff := cmp.Diff(tt.wantLen, got.Len()); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantLen, r.Len()); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+			if tt.want != nil && !got.Equal(tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if !rangeSet(0, 1000).IsSuperset(got) {
+				t.Errorf("got values %v not in the stream", got)
+			}
+		})
+	}
+}
+
+func TestReservoirDuplicates(t *testing.T) {
+	t.Parallel()
+
+	// The sample is the same however often the values are repeated, in any
+	// order.
+	r := set.NewReservoir[int](20)
+	for i := 0; i < 1000; i++ {
+		r.Add(i)
+	}
+
+	u := set.NewReservoir[int](20)
+	for i := 999; i >= 0; i-- {
+		for j := 0; j <= i%5; j++ {
+			u.Add(i)
+		}
+	}
+
+	if !r.Sample().Equal(u.Sample()) {
+		t.Errorf("got samples %v and %v", r.Sample(), u.Sample())
+	}
+}
+
+func TestReservoirMerge(t *testing.T) {
+	t.Parallel()
+
+	r, u, all := set.NewReservoir[int](50), set.NewReservoir[int](50), set.NewReservoir[int](50)
+	for i := 0; i < 1000; i++ {
+		r.Add(i)
+		all.Add(i)
+	}
+	for i := 500; i < 2000; i++ {
+		u.Add(i)
+		all.Add(i)
+	}
+
+	r.Merge(u)
+
+	if !r.Sample().Equal(all.Sample()) {
+		t.Errorf("got sample %v, want %v", r.Sample(), all.Sample())
+	}
+}
+
+func TestReservoirUniform(t *testing.T) {
+	t.Parallel()
+
+	// Values below 1000 make up half of the distinct values, and most of
+	// the stream, so they should make up about half of the sample.
+	r := set.NewReservoir[int](1000)
+	for i := 0; i < 2000; i++ {
+		n := 1
+		if i < 1000 {
+			n = 10
+		}
+		for j := 0; j < n; j++ {
+			r.Add(i)
+		}
+	}
+
+	var low int
+	for v := range r.Sample().All() {
+		if v < 1000 {
+			low++
+		}
+	}
+
+	// The standard deviation is about 11.
+	if math.Abs(float64(low)-500) > 80 {
+		t.Errorf("got %d sampled values below 1000, want about 500", low)
+	}
+}
@@ -1,37 +1,186 @@
 // Package set defines various methods for a set.
+//
+// Like a Go map, a Set is iterated in an unspecified order. Building with the
+// setdeterministic tag, for example with
+//
+//	go test -tags setdeterministic ./...
+//
+// makes All, Values, String and the other operations yielding values iterate
+// in sorted order instead, which keeps golden output stable in tests.
 package set
 
 import (
+	"errors"
 	"fmt"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"sync/atomic"
 )
 
+// ErrConcurrentModification is the value of the panic raised, when built with
+// the setdebug tag, by an iteration over a Set that was modified since the
+// iteration started.
+var ErrConcurrentModification = errors.New("set: set modified during iteration")
+
+// smallLen is the largest length of a Set stored in a slice rather than a map.
+const smallLen = 16
+
 // Set is a set of comparables.
+//
+// A Set is safe for concurrent use by multiple goroutines. Methods taking
+// another Set lock both, so that for example s.Union(t) sees consistent
+// versions of `s` and `t`.
+//
+// The zero value is an empty set ready to use, so that a Set can be embedded
+// in a struct without a constructor. A Set must not be copied after first use.
 type Set[V comparable] struct {
-	m map[V]struct{}
+	// mu guards all the other fields.
+	mu sync.RWMutex
+
+	// small holds the values while there are at most smallLen of them and
+	// m is nil; scanning a short slice is cheaper than hashing, and it saves
+	// the allocations of a map. Past smallLen, the values move to m.
+	small []V
+	m     map[V]struct{}
+
+	// version is incremented every time a value is inserted or deleted.
+	version uint64
+
+	// peak is the largest length `m` has reached, which bounds the memory
+	// it holds since maps never shrink.
+	peak int
+
+	// iterating counts the iterations in progress over `small`, which
+	// deletions must not reorder under them. Iterations may run under a
+	// read lock concurrently, hence the atomic.
+	iterating atomic.Int32
+
+	// cfg holds optional features and is nil unless one is in use, so that
+	// plain sets stay small.
+	cfg *config[V]
+}
+
+// config holds the optional features of a Set.
+type config[V comparable] struct {
+	onInsert []func(V)
+	onDelete []func(V)
+	metrics  Metrics
+	observer Observer
+	history  *history[V]
+
+	autoCompact float64
+
+	validator func(V) error
+	maxLen    int
+	overflow  OverflowPolicy
 }
 
 // New returns a Set from the given values.
 func New[V comparable](v ...V) *Set[V] {
-	s := &Set[V]{make(map[V]struct{})}
+	s := newSized[V](len(v))
 
 	s.Insert(v...)
 
 	return s
 }
 
+// newSized returns an empty Set with room for `n` values.
+func newSized[V comparable](n int) *Set[V] {
+	if n <= smallLen {
+		return &Set[V]{small: make([]V, 0, n)}
+	}
+
+	return &Set[V]{m: make(map[V]struct{}, n)}
+}
+
+// All returns an iterator over the values of `s`.
+//
+// `s` is locked while the next value is looked up but not while it is
+// yielded, so the loop body may modify `s` or call its methods. As with a
+// range over a map, values inserted or deleted during the iteration may or may
+// not be yielded, unless built with the setdebug tag.
+func (s *Set[V]) All() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		s.mu.RLock()
+		locked := true
+		defer func() {
+			if locked {
+				s.mu.RUnlock()
+			}
+		}()
+
+		version := s.version
+
+		for k := range ordered(s.values()) {
+			s.mu.RUnlock()
+			locked = false
+
+			ok := yield(k)
+
+			s.mu.RLock()
+			locked = true
+
+			if !ok {
+				return
+			}
+
+			s.checkVersion(version)
+		}
+	}
+}
+
+// Chunks returns an iterator over the values of `s` in slices of up to `n`
+// values, so that large sets can be exported in bounded batches without a
+// full copy. Each yielded slice is newly allocated.
+//
+// As with All, `s` is not locked while a slice is yielded, and values inserted
+// or deleted during the iteration may or may not be yielded. Chunks panics if
+// `n` is less than 1.
+func (s *Set[V]) Chunks(n int) iter.Seq[[]V] {
+	if n < 1 {
+		panic("set: chunk size must be at least 1")
+	}
+
+	return func(yield func([]V) bool) {
+		var chunk []V
+
+		for k := range s.All() {
+			if chunk == nil {
+				chunk = make([]V, 0, min(n, s.Len()))
+			}
+
+			if chunk = append(chunk, k); len(chunk) == n {
+				if !yield(chunk) {
+					return
+				}
+				chunk = nil
+			}
+		}
+
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
 // Clone returns a new Set that a copy of `s`.
 func (s *Set[V]) Clone() *Set[V] {
-	t := New[V]()
-
-	t.Insert(s.Values()...)
+	defer s.runlock(s.rlock("Clone", nil))
 
-	return t
+	return s.clone()
 }
 
 // Delete removes the given values from `s`.
 func (s *Set[V]) Delete(v ...V) {
+	defer s.unlock(s.lock("Delete"))
+
+	s.beginStep()
+	defer s.endStep()
+
 	for _, x := range v {
-		delete(s.m, x)
+		s.delete(x)
 	}
 }
 
@@ -44,11 +193,13 @@ func (s *Set[V]) Delete(v ...V) {
 //	s.Difference(t) = {a3}
 //	t.Difference(s) = {a4, a5}
 func (s *Set[V]) Difference(t *Set[V]) *Set[V] {
+	defer s.runlock(s.rlock("Difference", t))
+
 	u := New[V]()
 
-	for k := range s.m {
-		if !t.Contains(k) {
-			u.Insert(k)
+	for k := range s.values() {
+		if !t.contains(k) {
+			u.insert(k)
 		}
 	}
 
@@ -63,11 +214,13 @@ func (s *Set[V]) Difference(t *Set[V]) *Set[V] {
 //	t = {a2, a3}
 //	s.Intersection(t) = {a2}
 func (s *Set[V]) Intersection(t *Set[V]) *Set[V] {
+	defer s.runlock(s.rlock("Intersection", t))
+
 	u := New[V]()
 
 	var walk, other *Set[V]
 
-	if s.Len() < t.Len() {
+	if s.size() < t.size() {
 		walk = s
 		other = t
 	} else {
@@ -75,9 +228,9 @@ func (s *Set[V]) Intersection(t *Set[V]) *Set[V] {
 		other = s
 	}
 
-	for k := range walk.m {
-		if other.Contains(k) {
-			u.Insert(k)
+	for k := range walk.values() {
+		if other.contains(k) {
+			u.insert(k)
 		}
 	}
 
@@ -89,19 +242,24 @@ func (s *Set[V]) Intersection(t *Set[V]) *Set[V] {
 // Two sets are equal if their underlying values are identical not considering
 // order.
 func (s *Set[V]) Equal(t *Set[V]) bool {
-	return len(s.m) == len(t.m) && s.IsSuperset(t)
+	defer s.runlock(s.rlock("Equal", t))
+
+	return s.size() == t.size() && s.isSuperset(t)
 }
 
 // Contains returns true iff `s` contains a given value.
 func (s *Set[V]) Contains(v V) bool {
-	_, ok := s.m[v]
-	return ok
+	defer s.runlock(s.rlock("Contains", nil))
+
+	return s.contains(v)
 }
 
 // ContainsAll returns true iff `s` contains all the given values.
 func (s *Set[V]) ContainsAll(v ...V) bool {
+	defer s.runlock(s.rlock("ContainsAll", nil))
+
 	for _, x := range v {
-		if !s.Contains(x) {
+		if !s.contains(x) {
 			return false
 		}
 	}
@@ -111,8 +269,10 @@ func (s *Set[V]) ContainsAll(v ...V) bool {
 
 // ContainsAny returns true iff `s` contains any of the given values.
 func (s *Set[V]) ContainsAny(v ...V) bool {
+	defer s.runlock(s.rlock("ContainsAny", nil))
+
 	for _, x := range v {
-		if s.Contains(x) {
+		if s.contains(x) {
 			return true
 		}
 	}
@@ -122,35 +282,64 @@ func (s *Set[V]) ContainsAny(v ...V) bool {
 
 // Insert adds the given values to `s`.
 func (s *Set[V]) Insert(v ...V) {
+	defer s.unlock(s.lock("Insert"))
+
+	s.beginStep()
+	defer s.endStep()
+
 	for _, x := range v {
-		s.m[x] = struct{}{}
+		s.insert(x)
 	}
 }
 
 // IsSuperset returns true iff `t` is a superset of `s`.
 func (s *Set[V]) IsSuperset(t *Set[V]) bool {
-	for k := range t.m {
-		if !s.Contains(k) {
-			return false
-		}
-	}
+	defer s.runlock(s.rlock("IsSuperset", t))
 
-	return true
+	return s.isSuperset(t)
 }
 
 // Len returns the size of `s`.
 func (s *Set[V]) Len() int {
-	return len(s.m)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.size()
 }
 
 // PopAny returns a single value randomly chosen and removes it from `s`.
-func (s *Set[V]) PopAny() (v V, _ bool) {
-	for k := range s.m {
-		delete(s.m, k)
-		return k, true
+//
+// Choosing and removing the value happen under a single lock, so concurrent
+// calls never return the same value.
+func (s *Set[V]) PopAny() (V, bool) {
+	defer s.unlock(s.lock("PopAny"))
+
+	return s.popAny()
+}
+
+// TryPop is like PopAny but does not wait for the lock of `s`: it returns
+// false if `s` is empty or locked by another goroutine at the time of the
+// call.
+//
+// TryPop suits consumers polling a Set shared with producers, which would
+// rather do other work than wait.
+func (s *Set[V]) TryPop() (v V, _ bool) {
+	if !s.mu.TryLock() {
+		return v, false
 	}
+	defer s.unlock(s.observed(call[V]{}, "TryPop"))
 
-	return v, false
+	return s.popAny()
+}
+
+// Snapshot returns a copy of `s` along with the current version of `s`.
+//
+// The copy is independent of `s`, and comparing the version with a later call
+// to Version tells whether `s` has changed since.
+func (s *Set[V]) Snapshot() (*Set[V], uint64) {
+	defer s.runlock(s.rlock("Snapshot", nil))
+
+	return s.clone(), s.version
 }
 
 // String implements fmt.Stringer.
@@ -160,13 +349,27 @@ func (s *Set[V]) String() string {
 
 // Values returns the underlying values of `s` as a slice.
 func (s *Set[V]) Values() []V {
-	v := make([]V, 0, len(s.m))
+	defer s.runlock(s.rlock("Values", nil))
 
-	for k := range s.m {
-		v = append(v, k)
-	}
+	return s.appendValues(make([]V, 0, s.size()))
+}
+
+// AppendValues appends the underlying values of `s` to `dst` and returns the
+// extended slice, so that a buffer can be reused across calls.
+func (s *Set[V]) AppendValues(dst []V) []V {
+	defer s.runlock(s.rlock("AppendValues", nil))
+
+	return s.appendValues(dst)
+}
+
+// Version returns a counter that increases every time a value is inserted into
+// or deleted from `s`. Operations that leave `s` unchanged, such as inserting a
+// value already in `s`, do not change the version.
+func (s *Set[V]) Version() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
-	return v
+	return s.version
 }
 
 // Union returns a new Set whose values are included in either `s` or `t`.
@@ -178,11 +381,253 @@ func (s *Set[V]) Values() []V {
 //	s.Union(t) = {a1, a2, a3, a4}
 //	t.Union(s) = {a1, a2, a3, a4}
 func (s *Set[V]) Union(t *Set[V]) *Set[V] {
-	u := s.Clone()
+	defer s.runlock(s.rlock("Union", t))
 
-	for k := range t.m {
-		u.Insert(k)
+	u := s.clone()
+
+	for k := range t.values() {
+		u.insert(k)
 	}
 
 	return u
 }
+
+// The methods below expect `s` to be locked by their caller, for reading
+// unless they modify `s`.
+
+// size returns the length of `s`.
+func (s *Set[V]) size() int {
+	if s.m != nil {
+		return len(s.m)
+	}
+
+	return len(s.small)
+}
+
+// clone returns a copy of `s` without its options.
+func (s *Set[V]) clone() *Set[V] {
+	t := newSized[V](s.size())
+
+	for k := range s.values() {
+		t.insert(k)
+	}
+
+	return t
+}
+
+// contains is like has but counts the lookup in the Metrics of `s`.
+func (s *Set[V]) contains(v V) bool {
+	ok := s.has(v)
+
+	if s.cfg != nil && s.cfg.metrics != nil {
+		if ok {
+			s.cfg.metrics.Hit()
+		} else {
+			s.cfg.metrics.Miss()
+		}
+	}
+
+	return ok
+}
+
+// isSuperset returns true iff `s` contains all the values of `t`.
+func (s *Set[V]) isSuperset(t *Set[V]) bool {
+	for k := range t.values() {
+		if !s.contains(k) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// popAny removes and returns a random value of `s`.
+func (s *Set[V]) popAny() (v V, _ bool) {
+	s.beginStep()
+	defer s.endStep()
+
+	if s.m == nil {
+		if len(s.small) == 0 {
+			return v, false
+		}
+
+		v = s.small[rand.IntN(len(s.small))]
+		s.delete(v)
+
+		return v, true
+	}
+
+	for k := range s.m {
+		s.delete(k)
+		return k, true
+	}
+
+	return v, false
+}
+
+// appendValues appends the values of `s` to `dst`.
+func (s *Set[V]) appendValues(dst []V) []V {
+	for k := range ordered(s.values()) {
+		dst = append(dst, k)
+	}
+
+	return dst
+}
+
+// checkVersion panics with ErrConcurrentModification if `s` is no longer at
+// `version` and the package is built with the setdebug tag.
+func (s *Set[V]) checkVersion(version uint64) {
+	if failFast && s.version != version {
+		panic(ErrConcurrentModification)
+	}
+}
+
+// has returns true iff `s` contains `v`. Unlike Contains, it is neither
+// observed nor counted by Metrics.
+func (s *Set[V]) has(v V) bool {
+	if s.m != nil {
+		_, ok := s.m[v]
+		return ok
+	}
+
+	return slices.Contains(s.small, v)
+}
+
+// values returns an iterator over the values of `s` in storage order.
+//
+// Like a range over a map, it does not yield values deleted during the
+// iteration before they are reached, and may or may not yield values inserted
+// during the iteration.
+func (s *Set[V]) values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		version := s.version
+
+		if s.m != nil {
+			for k := range s.m {
+				if s.version != version && !s.has(k) {
+					continue
+				}
+				if !yield(k) {
+					return
+				}
+			}
+			return
+		}
+
+		s.iterating.Add(1)
+		defer s.iterating.Add(-1)
+
+		for _, k := range s.small {
+			if s.version != version && !s.has(k) {
+				continue
+			}
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// insert adds `v` to `s` and reports whether it was missing. All insertions go
+// through insert or tryInsert so that the version, hooks and limits stay
+// consistent.
+func (s *Set[V]) insert(v V) bool {
+	ok, _ := s.tryInsert(v)
+	return ok
+}
+
+// tryInsert is like insert but returns the error rejecting `v` if `s` is
+// configured by WithValidator or WithMaxLen.
+func (s *Set[V]) tryInsert(v V) (bool, error) {
+	if s.has(v) {
+		return false, nil
+	}
+
+	if s.cfg != nil {
+		if ok, err := s.admit(v); !ok {
+			return false, err
+		}
+	}
+
+	switch {
+	case s.m != nil:
+		s.m[v] = struct{}{}
+		s.peak = max(s.peak, len(s.m))
+	case len(s.small) < smallLen:
+		s.small = append(s.small, v)
+	default:
+		s.m = make(map[V]struct{}, 2*smallLen)
+		for _, k := range s.small {
+			s.m[k] = struct{}{}
+		}
+		s.m[v] = struct{}{}
+		s.small = nil
+		s.peak = len(s.m)
+	}
+
+	s.version++
+	s.recordInsert(v)
+
+	if s.cfg != nil {
+		if s.cfg.metrics != nil {
+			s.cfg.metrics.Insert()
+		}
+		for _, f := range s.cfg.onInsert {
+			f(v)
+		}
+	}
+
+	return true, nil
+}
+
+// delete removes `v` from `s` and reports whether it was present. All
+// deletions go through delete so that the version and hooks stay consistent.
+func (s *Set[V]) delete(v V) bool {
+	if s.m != nil {
+		if _, ok := s.m[v]; !ok {
+			return false
+		}
+
+		delete(s.m, v)
+	} else {
+		i := slices.Index(s.small, v)
+		if i < 0 {
+			return false
+		}
+
+		// Iterations in progress range over the current backing array, so
+		// it is copied rather than reordered under them.
+		if s.iterating.Load() > 0 {
+			s.small = slices.Clone(s.small)
+		}
+
+		last := len(s.small) - 1
+		s.small[i] = s.small[last]
+		s.small[last] = *new(V)
+		s.small = s.small[:last]
+	}
+
+	s.version++
+	s.recordDelete(v)
+	s.maybeCompact()
+
+	if s.cfg != nil {
+		if s.cfg.metrics != nil {
+			s.cfg.metrics.Delete()
+		}
+		for _, f := range s.cfg.onDelete {
+			f(v)
+		}
+	}
+
+	return true
+}
+
+// config returns the config of `s`, allocating it if needed.
+func (s *Set[V]) config() *config[V] {
+	if s.cfg == nil {
+		s.cfg = &config[V]{}
+	}
+
+	return s.cfg
+}
@@ -0,0 +1,97 @@
+//go:build !setdebug
+
+package set_test
+
+// The tests in this file modify sets during an iteration over them, which the
+// setdebug tag reports as a concurrent modification.
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestSetDeleteDuringIteration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.Set[int]
+		want []int
+	}{
+		{
+			name: "small",
+			s:    rangeSet(0, 8),
+			want: []int{},
+		},
+		{
+			name: "large",
+			s:    rangeSet(0, 100),
+			want: []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			n := tt.s.Len()
+			for k := range tt.s.All() {
+				tt.s.Delete(k, n-1-k)
+			}
+
+			if diff := cmp.Diff(tt.want, sortedValues(tt.s), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetConcurrentReadWrite(t *testing.T) {
+	t.Parallel()
+
+	s, u := rangeSet(0, 100), rangeSet(50, 150)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				// Locking both sets in opposite orders must not
+				// deadlock.
+				if i%2 == 0 {
+					s.Union(u)
+					s.Equal(u)
+				} else {
+					u.Intersection(s)
+					u.IsSuperset(s)
+				}
+
+				s.Insert(j)
+				u.Delete(j)
+				s.ApplyPatch(set.Patch[int]{Removed: s, Added: u})
+
+				var deleted []int
+				for k := range s.All() {
+					s.Contains(k)
+					if k%7 == 0 {
+						deleted = append(deleted, k)
+					}
+				}
+				s.Delete(deleted...)
+				_ = s.String()
+				_ = set.UnionAll(s, u, s)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
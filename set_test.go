@@ -1,6 +1,11 @@
 package set_test
 
 import (
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -17,6 +22,121 @@ func equal(t *testing.T) func(*set.Set[int], *set.Set[int]) bool {
 	}
 }
 
+// rangeSet returns a Set of the integers in [lo, hi).
+func rangeSet(lo, hi int) *set.Set[int] {
+	s := set.New[int]()
+	for i := lo; i < hi; i++ {
+		s.Insert(i)
+	}
+
+	return s
+}
+
+// sortedValues returns the values of `s` in ascending order.
+func sortedValues(s *set.Set[int]) []int {
+	v := s.Values()
+	slices.Sort(v)
+
+	return v
+}
+
+// approx returns a comparer that reports whether two floats are within the
+// given relative error of each other.
+func approx(rel float64) func(x, y float64) bool {
+	return func(x, y float64) bool {
+		return math.Abs(x-y) <= rel*math.Max(math.Abs(x), math.Abs(y))
+	}
+}
+
+func TestSetAll(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.Set[int]
+		want []int
+	}{
+		{
+			name: "all",
+			s:    set.New(1, 2, 3),
+			want: []int{1, 2, 3},
+		},
+		{
+			name: "empty",
+			s:    set.New[int](),
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, slices.Sorted(tt.s.All())); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetChunks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		s        *set.Set[int]
+		n        int
+		wantLens []int
+	}{
+		{
+			name:     "chunks",
+			s:        set.New(1, 2, 3, 4, 5),
+			n:        2,
+			wantLens: []int{2, 2, 1},
+		},
+		{
+			name:     "single chunk",
+			s:        set.New(1, 2),
+			n:        10,
+			wantLens: []int{2},
+		},
+		{
+			name:     "empty",
+			s:        set.New[int](),
+			n:        2,
+			wantLens: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				lens []int
+				got  []int
+			)
+			for c := range tt.s.Chunks(tt.n) {
+				lens = append(lens, len(c))
+				got = append(got, c...)
+			}
+
+			if diff := cmp.Diff(tt.wantLens, lens); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(sortedValues(tt.s), got, cmpopts.EquateEmpty(), cmpopts.SortSlices(func(i, j int) bool {
+				return i < j
+			})); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestSetDelete(t *testing.T) {
 	t.Parallel()
 
@@ -408,6 +528,68 @@ func TestSetPopAny(t *testing.T) {
 	}
 }
 
+func TestSetSnapshot(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		s           *set.Set[int]
+		f           func(*set.Set[int])
+		wantChanged bool
+	}{
+		{
+			name:        "insert",
+			s:           set.New(1),
+			f:           func(s *set.Set[int]) { s.Insert(2) },
+			wantChanged: true,
+		},
+		{
+			name:        "delete",
+			s:           set.New(1),
+			f:           func(s *set.Set[int]) { s.Delete(1) },
+			wantChanged: true,
+		},
+		{
+			name:        "pop",
+			s:           set.New(1),
+			f:           func(s *set.Set[int]) { s.PopAny() },
+			wantChanged: true,
+		},
+		{
+			name:        "insert existing",
+			s:           set.New(1),
+			f:           func(s *set.Set[int]) { s.Insert(1) },
+			wantChanged: false,
+		},
+		{
+			name:        "delete missing",
+			s:           set.New(1),
+			f:           func(s *set.Set[int]) { s.Delete(2) },
+			wantChanged: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			want := tt.s.Clone()
+			snapshot, version := tt.s.Snapshot()
+
+			tt.f(tt.s)
+
+			if diff := cmp.Diff(tt.wantChanged, tt.s.Version() > version); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(want, snapshot); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestSetString(t *testing.T) {
 	t.Parallel()
 
@@ -466,6 +648,63 @@ func TestSetValues(t *testing.T) {
 	}
 }
 
+func TestSetAppendValues(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.Set[int]
+		dst  []int
+		want []int
+	}{
+		{
+			name: "append to nil",
+			s:    set.New(1, 2),
+			dst:  nil,
+			want: []int{1, 2},
+		},
+		{
+			name: "append to buffer",
+			s:    set.New(2, 3),
+			dst:  []int{1},
+			want: []int{1, 2, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, tt.s.AppendValues(tt.dst), cmpopts.SortSlices(func(i, j int) bool {
+				return i < j
+			})); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func BenchmarkSetValues(b *testing.B) {
+	s := rangeSet(0, 1000)
+
+	b.Run("Values", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = s.Values()
+		}
+	})
+
+	b.Run("AppendValues", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf []int
+		for i := 0; i < b.N; i++ {
+			buf = s.AppendValues(buf[:0])
+		}
+	})
+}
+
 func TestSetUnion(t *testing.T) {
 	t.Parallel()
 
@@ -495,3 +734,171 @@ func TestSetUnion(t *testing.T) {
 		})
 	}
 }
+
+func TestSetPromotion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.Set[int]
+		f    func(*set.Set[int])
+		want []int
+	}{
+		{
+			name: "grow past small length",
+			s:    rangeSet(0, 16),
+			f:    func(s *set.Set[int]) { s.Insert(16, 17) },
+			want: sortedValues(rangeSet(0, 18)),
+		},
+		{
+			name: "shrink and compact",
+			s:    rangeSet(0, 100),
+			f: func(s *set.Set[int]) {
+				for i := 3; i < 100; i++ {
+					s.Delete(i)
+				}
+				s.Compact()
+				s.Insert(3)
+			},
+			want: []int{0, 1, 2, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.f(tt.s)
+
+			if diff := cmp.Diff(tt.want, sortedValues(tt.s), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// setSink keeps the sets built by benchmarks escaping to the heap, as they
+// would in a real program.
+var setSink *set.Set[int]
+
+func BenchmarkSetSmall(b *testing.B) {
+	// Small sets are stored in a slice, and larger ones in a map.
+	for _, n := range []int{4, 64} {
+		v := rangeSet(0, n).Values()
+
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				setSink = set.New(v...)
+				_ = setSink.Contains(3)
+			}
+		})
+	}
+}
+
+func TestSetZeroValue(t *testing.T) {
+	t.Parallel()
+
+	var (
+		s    set.Set[int]
+		zero set.Set[int]
+	)
+
+	if diff := cmp.Diff(0, s.Len()); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(false, s.Contains(1)); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(true, s.Equal(set.New[int]())); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff("[]", s.String()); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+	if _, ok := s.PopAny(); ok {
+		t.Error("popped a value from an empty set")
+	}
+	if diff := cmp.Diff(set.New(1), set.New(1).Union(&zero)); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+
+	s.OnInsert(func(int) {})
+	s.Insert(rangeSet(0, 100).Values()...)
+	s.Delete(0)
+
+	if diff := cmp.Diff(rangeSet(1, 100), &s); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
+
+func TestSetConcurrentPop(t *testing.T) {
+	t.Parallel()
+
+	const (
+		producers = 4
+		consumers = 4
+		n         = 1000
+	)
+
+	s := set.New[int]()
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		popped = make(map[int]int)
+		total  atomic.Int64
+	)
+
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := p * n; i < (p+1)*n; i++ {
+				s.Insert(i)
+			}
+		}()
+	}
+
+	for c := 0; c < consumers; c++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for total.Load() < producers*n {
+				var (
+					v  int
+					ok bool
+				)
+				if c%2 == 1 {
+					v, ok = s.TryPop()
+				} else {
+					v, ok = s.PopAny()
+				}
+				if !ok {
+					continue
+				}
+
+				total.Add(1)
+				mu.Lock()
+				popped[v]++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if diff := cmp.Diff(producers*n, len(popped)); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+	for v, count := range popped {
+		if count != 1 {
+			t.Errorf("popped %d %d times", v, count)
+		}
+	}
+	if diff := cmp.Diff(0, s.Len()); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,87 @@
+// Package setcmp provides options for github.com/google/go-cmp/cmp to compare
+// sets by their values, including sets nested in other types, whether they
+// are held by pointer or by value:
+//
+//	type config struct {
+//		Name  string
+//		Tags  *set.Set[string]
+//		Roles set.Set[string]
+//	}
+//
+//	diff := cmp.Diff(&want, &got, setcmp.Transform[string]())
+//
+// On its own, cmp compares a *set.Set with its Equal method, which reports two
+// different sets as a whole and panics if only one of them is nil, and panics
+// on the unexported fields of a set.Set held by value. Each option applies to
+// sets of a single type of value.
+package setcmp
+
+import (
+	"reflect"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+// Transform returns an option comparing sets of `V` as maps of their values,
+// so that differences are reported value by value.
+//
+// A nil set is transformed into a nil map, which cmp reports as different
+// from an empty set unless cmpopts.EquateEmpty is given as well.
+func Transform[V comparable]() cmp.Option {
+	return cmp.Options{
+		cmp.Transformer("set.Set", toMap[V]),
+		cmp.FilterPath(isValue[V], cmp.Transformer("set.Set", func(v any) map[V]struct{} {
+			return toMap(pointer[V](v))
+		})),
+	}
+}
+
+// Compare returns an option comparing sets of `V` with Equal. It is cheaper
+// than Transform, but differences are reported as a whole set.
+//
+// Nil sets are equal only to nil sets.
+func Compare[V comparable]() cmp.Option {
+	return cmp.Options{
+		cmp.Comparer(equal[V]),
+		cmp.FilterPath(isValue[V], cmp.Comparer(func(s, t any) bool {
+			return equal(pointer[V](s), pointer[V](t))
+		})),
+	}
+}
+
+func toMap[V comparable](s *set.Set[V]) map[V]struct{} {
+	if s == nil {
+		return nil
+	}
+
+	m := make(map[V]struct{}, s.Len())
+	for v := range s.All() {
+		m[v] = struct{}{}
+	}
+
+	return m
+}
+
+func equal[V comparable](s, t *set.Set[V]) bool {
+	if s == nil || t == nil {
+		return s == t
+	}
+
+	return s.Equal(t)
+}
+
+// isValue reports whether the last step of `p` is a set of `V` held by value.
+func isValue[V comparable](p cmp.Path) bool {
+	return p.Last().Type() == reflect.TypeFor[set.Set[V]]()
+}
+
+// pointer returns a pointer to a copy of the set of `V` held by `v`, since the
+// methods of a set need a pointer to it.
+func pointer[V comparable](v any) *set.Set[V] {
+	p := reflect.New(reflect.TypeFor[set.Set[V]]())
+	p.Elem().Set(reflect.ValueOf(v))
+
+	return p.Interface().(*set.Set[V])
+}
@@ -0,0 +1,170 @@
+package setcmp_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+	"github.com/micnncim/go-set/setcmp"
+)
+
+type config struct {
+	Name string
+	Tags *set.Set[string]
+}
+
+func TestTransform(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		x, y     config
+		wantDiff []string
+	}{
+		{
+			name: "equal",
+			x:    config{Name: "a", Tags: set.New("foo", "bar", "baz")},
+			y:    config{Name: "a", Tags: set.New("baz", "foo", "bar")},
+		},
+		{
+			name:     "different",
+			x:        config{Name: "a", Tags: set.New("foo", "bar")},
+			y:        config{Name: "a", Tags: set.New("foo", "baz")},
+			wantDiff: []string{`"bar"`, `"baz"`},
+		},
+		{
+			name: "both nil",
+			x:    config{Name: "a"},
+			y:    config{Name: "a"},
+		},
+		{
+			name:     "nil and empty",
+			x:        config{Name: "a"},
+			y:        config{Name: "a", Tags: set.New[string]()},
+			wantDiff: []string{"Tags"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			diff := cmp.Diff(tt.x, tt.y, setcmp.Transform[string]())
+			if len(tt.wantDiff) == 0 && diff != "" {
+				t.Errorf("got a diff of equal values:\n%s", diff)
+			}
+			for _, want := range tt.wantDiff {
+				if !strings.Contains(diff, want) {
+					t.Errorf("got a diff without %q:\n%s", want, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		x, y config
+		want bool
+	}{
+		{
+			name: "equal",
+			x:    config{Name: "a", Tags: set.New("foo", "bar", "baz")},
+			y:    config{Name: "a", Tags: set.New("baz", "foo", "bar")},
+			want: true,
+		},
+		{
+			name: "different",
+			x:    config{Name: "a", Tags: set.New("foo", "bar")},
+			y:    config{Name: "a", Tags: set.New("foo", "baz")},
+			want: false,
+		},
+		{
+			name: "both nil",
+			x:    config{Name: "a"},
+			y:    config{Name: "a"},
+			want: true,
+		},
+		{
+			name: "nil and empty",
+			x:    config{Name: "a"},
+			y:    config{Name: "a", Tags: set.New[string]()},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, cmp.Equal(tt.x, tt.y, setcmp.Compare[string]())); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+type user struct {
+	Name  string
+	Roles set.Set[string]
+}
+
+func TestValueSets(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		x, y     []string
+		wantDiff []string
+	}{
+		{
+			name: "equal",
+			x:    []string{"foo", "bar", "baz"},
+			y:    []string{"baz", "foo", "bar"},
+		},
+		{
+			name:     "different",
+			x:        []string{"foo", "bar"},
+			y:        []string{"foo", "baz"},
+			wantDiff: []string{`"bar"`, `"baz"`},
+		},
+		{
+			name: "zero values",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			x, y := user{Name: "a"}, user{Name: "a"}
+			x.Roles.Insert(tt.x...)
+			y.Roles.Insert(tt.y...)
+
+			diff := cmp.Diff(&x, &y, setcmp.Transform[string]())
+			if len(tt.wantDiff) == 0 && diff != "" {
+				t.Errorf("got a diff of equal values:\n%s", diff)
+			}
+			for _, want := range tt.wantDiff {
+				if !strings.Contains(diff, want) {
+					t.Errorf("got a diff without %q:\n%s", want, diff)
+				}
+			}
+
+			if diff := cmp.Diff(len(tt.wantDiff) == 0, cmp.Equal(&x, &y, setcmp.Compare[string]())); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package set
+
+import (
+	"iter"
+	"sync"
+)
+
+// SetMap maps keys to sets of values, creating the set of a key when a value
+// is first added to it and deleting it once its last value is deleted.
+//
+// The zero value is an empty SetMap ready to use, and a SetMap is safe for
+// concurrent use. Its sets are never shared with the caller, so that they can
+// only be modified through the SetMap.
+type SetMap[K, V comparable] struct {
+	mu sync.RWMutex
+	m  map[K]*Set[V]
+}
+
+// Add adds the given values to the set of `k`.
+func (m *SetMap[K, V]) Add(k K, v ...V) {
+	if len(v) == 0 {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.m[k]
+	if !ok {
+		if m.m == nil {
+			m.m = make(map[K]*Set[V])
+		}
+
+		s = newSized[V](len(v))
+		m.m[k] = s
+	}
+
+	for _, x := range v {
+		s.insert(x)
+	}
+}
+
+// Has returns true iff the set of `k` contains `v`.
+func (m *SetMap[K, V]) Has(k K, v V) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	s, ok := m.m[k]
+	return ok && s.has(v)
+}
+
+// DeleteValue removes `v` from the set of `k`, and reports whether it was
+// present. The key is deleted along with its last value.
+func (m *SetMap[K, V]) DeleteValue(k K, v V) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.m[k]
+	if !ok || !s.delete(v) {
+		return false
+	}
+
+	if s.size() == 0 {
+		delete(m.m, k)
+	}
+
+	return true
+}
+
+// Delete removes `k` and its set.
+func (m *SetMap[K, V]) Delete(k K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.m, k)
+}
+
+// Get returns a copy of the set of `k`, which is empty if `k` has no values.
+func (m *SetMap[K, V]) Get(k K) *Set[V] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	s, ok := m.m[k]
+	if !ok {
+		return New[V]()
+	}
+
+	return s.clone()
+}
+
+// KeysFor returns a new Set of the keys whose sets contain `v`, looking up
+// the set of every key.
+func (m *SetMap[K, V]) KeysFor(v V) *Set[K] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	keys := New[K]()
+	for k, s := range m.m {
+		if s.has(v) {
+			keys.insert(k)
+		}
+	}
+
+	return keys
+}
+
+// Keys returns a new Set of the keys of `m`.
+func (m *SetMap[K, V]) Keys() *Set[K] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	keys := newSized[K](len(m.m))
+	for k := range m.m {
+		keys.insert(k)
+	}
+
+	return keys
+}
+
+// Len returns the number of keys of `m`.
+func (m *SetMap[K, V]) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return len(m.m)
+}
+
+// AllFor returns an iterator over the values of the set of `k`.
+//
+// The values are copied before being yielded, so the loop body may modify
+// `m`.
+func (m *SetMap[K, V]) AllFor(k K) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range m.values(k) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the keys of `m` with each value of their sets.
+//
+// The values of a key are copied before being yielded, one key at a time, so
+// the loop body may modify `m`. As with a range over a map, keys and values
+// added or deleted during the iteration may or may not be yielded.
+func (m *SetMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k := range m.Keys().All() {
+			for _, v := range m.values(k) {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// values returns a copy of the values of the set of `k`.
+func (m *SetMap[K, V]) values(k K) []V {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	s, ok := m.m[k]
+	if !ok {
+		return nil
+	}
+
+	return s.appendValues(make([]V, 0, s.size()))
+}
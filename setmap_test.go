@@ -0,0 +1,131 @@
+package set_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestSetMapAddDelete(t *testing.T) {
+	t.Parallel()
+
+	var m set.SetMap[string, int]
+
+	m.Add("a", 1, 2, 3)
+	m.Add("b", 2)
+	m.Add("c")
+
+	if diff := cmp.Diff(2, m.Len()); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+	if !m.Has("a", 3) || m.Has("a", 4) || m.Has("c", 1) {
+		t.Error("got the wrong membership")
+	}
+
+	if diff := cmp.Diff(set.New("a", "b"), m.KeysFor(2), cmp.Comparer(stringsEqual)); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(set.New[string](), m.KeysFor(4), cmp.Comparer(stringsEqual)); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+
+	// Get returns a copy.
+	m.Get("a").Insert(10)
+	if m.Has("a", 10) {
+		t.Error("modifying the result of Get modified the SetMap")
+	}
+
+	if !m.DeleteValue("b", 2) || m.DeleteValue("b", 2) {
+		t.Error("got the wrong result deleting a value")
+	}
+	if diff := cmp.Diff(set.New("a"), m.Keys(), cmp.Comparer(stringsEqual)); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(set.New[int](), m.Get("b"), cmp.Comparer(equal(t))); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+
+	m.Delete("a")
+	if diff := cmp.Diff(0, m.Len()); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
+
+func TestSetMapAll(t *testing.T) {
+	t.Parallel()
+
+	var m set.SetMap[string, int]
+	m.Add("a", 1, 2)
+	m.Add("b", 3)
+
+	type pair struct {
+		K string
+		V int
+	}
+
+	got := set.New[pair]()
+	for k, v := range m.All() {
+		got.Insert(pair{k, v})
+
+		// The loop body may modify the SetMap.
+		m.DeleteValue(k, v)
+	}
+
+	want := set.New(pair{"a", 1}, pair{"a", 2}, pair{"b", 3})
+	if !got.Equal(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if diff := cmp.Diff(0, m.Len()); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+
+	m.Add("c", 4, 5)
+
+	var values []int
+	for v := range m.AllFor("c") {
+		values = append(values, v)
+	}
+	if diff := cmp.Diff(2, len(values)); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+	for range m.AllFor("missing") {
+		t.Error("got a value of a missing key")
+	}
+}
+
+func TestSetMapConcurrent(t *testing.T) {
+	t.Parallel()
+
+	var (
+		m  set.SetMap[int, int]
+		wg sync.WaitGroup
+	)
+
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				m.Add(i%10, w*100+i)
+				m.Has(i%10, i)
+				m.KeysFor(i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var n int
+	for range m.All() {
+		n++
+	}
+	if diff := cmp.Diff(400, n); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
+
+func stringsEqual(s, t *set.Set[string]) bool {
+	return s.Equal(t)
+}
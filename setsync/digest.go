@@ -0,0 +1,295 @@
+// Package setsync reconciles replicas of a set by exchanging digests whose size
+// depends on the number of differences between the replicas rather than on the
+// size of the set.
+//
+// A replica sends a Digest of its set, and the other replica calls Diff with
+// its own set to recover the values to insert and delete:
+//
+//	d := setsync.NewDigest(remote, set.StringCodec{}, 100)
+//	// Send d.MarshalBinary() to the other replica.
+//	p, err := setsync.Diff(local, d, set.StringCodec{})
+//	local.ApplyPatch(p)
+//
+// Digests are invertible Bloom lookup tables: values are hashed with FNV over
+// their encoding, so digests built in different processes are compatible.
+package setsync
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/micnncim/go-set"
+)
+
+// hashes is the number of cells each value is added to.
+const hashes = 3
+
+// ErrTooManyDifferences is returned by Diff when the differences between the
+// sets cannot be recovered from the digest, usually because there are more
+// than it was sized for. A larger digest should be exchanged.
+var ErrTooManyDifferences = errors.New("setsync: too many differences for the digest size")
+
+// Digest is a compact summary of a set from which the differences with
+// another set can be recovered.
+type Digest struct {
+	cells []cell
+}
+
+// cell accumulates the values added to it. A cell holding a single value,
+// with a count of 1 or -1, can be decoded.
+type cell struct {
+	count   int64
+	keySum  []byte
+	lenSum  uint64
+	hashSum uint64
+}
+
+// NewDigest returns a Digest of `s`, whose values are encoded by `c`, able to
+// recover up to about `n` differences with another set. Recovering fewer
+// differences still fails with a small probability, below 1% for `n` of a few
+// hundred.
+//
+// NewDigest panics if `n` is less than 1.
+func NewDigest[V comparable](s *set.Set[V], c set.Codec[V], n int) *Digest {
+	if n < 1 {
+		panic("setsync: digest must recover at least 1 difference")
+	}
+
+	// About 1.5 cells per difference are needed when there are many of
+	// them; a few more keep small digests from failing.
+	cells := (n + n/2 + 4*hashes + hashes - 1) / hashes * hashes
+
+	return newDigest(s, c, cells)
+}
+
+func newDigest[V comparable](s *set.Set[V], c set.Codec[V], cells int) *Digest {
+	d := &Digest{cells: make([]cell, cells)}
+
+	var buf []byte
+	for v := range s.All() {
+		buf = c.Append(buf[:0], v)
+		d.add(buf, 1)
+	}
+
+	return d
+}
+
+// Diff returns the Patch turning `local` into the set summarized by `remote`,
+// whose values are encoded by `c`.
+//
+// Diff returns ErrTooManyDifferences if the sets differ by more values than
+// `remote` can recover.
+func Diff[V comparable](local *set.Set[V], remote *Digest, c set.Codec[V]) (set.Patch[V], error) {
+	if len(remote.cells) == 0 {
+		return set.Patch[V]{}, errors.New("setsync: empty digest")
+	}
+
+	p := set.Patch[V]{Added: set.New[V](), Removed: set.New[V]()}
+
+	d := newDigest(local, c, len(remote.cells))
+	for i := range d.cells {
+		d.cells[i].merge(&remote.cells[i], -1)
+	}
+
+	// Values only in `remote` are left with a count of -1, and values only
+	// in `local` with a count of 1.
+	// Removing a value from its cells may leave other cells pure, so they
+	// are checked again.
+	queue := make([]int, len(d.cells))
+	for i := range queue {
+		queue[i] = i
+	}
+
+	for len(queue) > 0 {
+		i := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+
+		if !d.cells[i].pure() {
+			continue
+		}
+
+		key := d.cells[i].key()
+		sign := d.cells[i].count
+
+		v, err := c.Decode(key)
+		if err != nil {
+			return set.Patch[V]{}, err
+		}
+		if sign < 0 {
+			p.Added.Insert(v)
+		} else {
+			p.Removed.Insert(v)
+		}
+
+		d.add(key, -sign)
+
+		idx := d.indexes(key)
+		queue = append(queue, idx[:]...)
+	}
+
+	for i := range d.cells {
+		if !d.cells[i].empty() {
+			return set.Patch[V]{}, ErrTooManyDifferences
+		}
+	}
+
+	return p, nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (d *Digest) MarshalBinary() ([]byte, error) {
+	b := binary.AppendUvarint(nil, uint64(len(d.cells)))
+
+	for _, c := range d.cells {
+		b = binary.AppendVarint(b, c.count)
+		b = binary.AppendUvarint(b, c.lenSum)
+		b = binary.BigEndian.AppendUint64(b, c.hashSum)
+		b = binary.AppendUvarint(b, uint64(len(c.keySum)))
+		b = append(b, c.keySum...)
+	}
+
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (d *Digest) UnmarshalBinary(b []byte) error {
+	errCorrupt := errors.New("setsync: corrupt digest")
+
+	n, w := binary.Uvarint(b)
+	if w <= 0 || n == 0 || n%hashes != 0 || n > uint64(len(b)) {
+		return errCorrupt
+	}
+	b = b[w:]
+
+	cells := make([]cell, n)
+
+	for i := range cells {
+		c := &cells[i]
+
+		if c.count, w = binary.Varint(b); w <= 0 {
+			return errCorrupt
+		}
+		b = b[w:]
+
+		if c.lenSum, w = binary.Uvarint(b); w <= 0 {
+			return errCorrupt
+		}
+		b = b[w:]
+
+		if len(b) < 8 {
+			return errCorrupt
+		}
+		c.hashSum = binary.BigEndian.Uint64(b)
+		b = b[8:]
+
+		size, w := binary.Uvarint(b)
+		if w <= 0 || size > uint64(len(b)-w) {
+			return errCorrupt
+		}
+		c.keySum = append([]byte(nil), b[w:w+int(size)]...)
+		b = b[w+int(size):]
+	}
+
+	if len(b) > 0 {
+		return fmt.Errorf("%w: %d trailing bytes", errCorrupt, len(b))
+	}
+
+	d.cells = cells
+
+	return nil
+}
+
+// add adds `key` to the cells of `d` it hashes to, `sign` times.
+func (d *Digest) add(key []byte, sign int64) {
+	h := hashKey(key)
+	for _, i := range d.indexes(key) {
+		d.cells[i].addKey(key, h, sign)
+	}
+}
+
+// indexes returns the cells of `d` that `key` hashes to, one in each of the
+// `hashes` equal parts of the cells, so that they are distinct.
+func (d *Digest) indexes(key []byte) [hashes]int {
+	part := uint64(len(d.cells) / hashes)
+
+	f := fnv.New64a()
+	f.Write(key)
+	h := f.Sum64()
+
+	var idx [hashes]int
+	for i := range idx {
+		h = mix(h)
+		idx[i] = i*int(part) + int(h%part)
+	}
+
+	return idx
+}
+
+// hashKey returns the checksum of `key` kept in cells, which tells whether a
+// cell holds a single value.
+func hashKey(key []byte) uint64 {
+	f := fnv.New64()
+	f.Write(key)
+	return mix(f.Sum64())
+}
+
+// mix returns the next output of a SplitMix64 generator in state `x`, which
+// spreads the bits of FNV hashes of similar values.
+func mix(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ x>>30) * 0xbf58476d1ce4e5b9
+	x = (x ^ x>>27) * 0x94d049bb133111eb
+	return x ^ x>>31
+}
+
+func (c *cell) addKey(key []byte, h uint64, sign int64) {
+	c.count += sign
+	c.lenSum ^= uint64(len(key))
+	c.hashSum ^= h
+
+	if len(c.keySum) < len(key) {
+		c.keySum = append(c.keySum, make([]byte, len(key)-len(c.keySum))...)
+	}
+	for i, b := range key {
+		c.keySum[i] ^= b
+	}
+}
+
+func (c *cell) merge(o *cell, sign int64) {
+	c.count += sign * o.count
+	c.lenSum ^= o.lenSum
+	c.hashSum ^= o.hashSum
+
+	if len(c.keySum) < len(o.keySum) {
+		c.keySum = append(c.keySum, make([]byte, len(o.keySum)-len(c.keySum))...)
+	}
+	for i, b := range o.keySum {
+		c.keySum[i] ^= b
+	}
+}
+
+// pure returns true iff `c` holds a single value.
+func (c *cell) pure() bool {
+	return (c.count == 1 || c.count == -1) && c.lenSum <= uint64(len(c.keySum)) && hashKey(c.key()) == c.hashSum
+}
+
+// key returns a copy of the value held by `c`, assuming it holds one.
+func (c *cell) key() []byte {
+	return append([]byte(nil), c.keySum[:c.lenSum]...)
+}
+
+func (c *cell) empty() bool {
+	if c.count != 0 || c.lenSum != 0 || c.hashSum != 0 {
+		return false
+	}
+
+	for _, b := range c.keySum {
+		if b != 0 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,108 @@
+package setsync_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+	"github.com/micnncim/go-set/setsync"
+)
+
+func rangeSet(lo, hi int) *set.Set[string] {
+	s := set.New[string]()
+	for i := lo; i < hi; i++ {
+		s.Insert(fmt.Sprint(i))
+	}
+	return s
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		local  *set.Set[string]
+		remote *set.Set[string]
+		n      int
+	}{
+		{
+			name:   "equal",
+			local:  rangeSet(0, 1000),
+			remote: rangeSet(0, 1000),
+			n:      1,
+		},
+		{
+			name:   "few differences",
+			local:  rangeSet(0, 1000),
+			remote: rangeSet(3, 1002),
+			n:      10,
+		},
+		{
+			name:   "many differences",
+			local:  rangeSet(0, 10000),
+			remote: rangeSet(100, 10100),
+			n:      300,
+		},
+		{
+			name:   "values of different lengths",
+			local:  set.New("", "a", "bb"),
+			remote: set.New("a", "ccc", "dddddddd"),
+			n:      5,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := setsync.NewDigest(tt.remote, set.StringCodec{}, tt.n).MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var d setsync.Digest
+			if err := d.UnmarshalBinary(b); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := setsync.Diff(tt.local, &d, set.StringCodec{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(set.Diff(tt.local, tt.remote), got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiffTooManyDifferences(t *testing.T) {
+	t.Parallel()
+
+	d := setsync.NewDigest(rangeSet(0, 1000), set.StringCodec{}, 10)
+
+	if _, err := setsync.Diff(rangeSet(500, 1500), d, set.StringCodec{}); !errors.Is(err, setsync.ErrTooManyDifferences) {
+		t.Errorf("got error %v, want %v", err, setsync.ErrTooManyDifferences)
+	}
+}
+
+func TestDigestUnmarshalBinary(t *testing.T) {
+	t.Parallel()
+
+	b, err := setsync.NewDigest(rangeSet(0, 10), set.StringCodec{}, 10).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, in := range [][]byte{nil, {0}, b[:len(b)-1], append(b, 0)} {
+		var d setsync.Digest
+		if err := d.UnmarshalBinary(in); err == nil {
+			t.Errorf("got no error unmarshaling %d bytes", len(in))
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Package settest provides test assertions for sets, reporting the values
+// that differ rather than whole sets:
+//
+//	func TestTags(t *testing.T) {
+//		settest.Equal(t, set.New("a", "b"), tags())
+//	}
+//
+// fails with a message such as:
+//
+//	sets differ: missing 1 value: [b], extra 2 values: [c d]
+//
+// Values are reported in the sorted order of set.FormatOptions.
+package settest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/micnncim/go-set"
+)
+
+// Equal reports an error to `t` if `got` does not have the same values as
+// `want`, listing the values missing from `got` and the extra ones. It returns
+// true iff the sets are equal.
+func Equal[V comparable](t testing.TB, want, got *set.Set[V]) bool {
+	t.Helper()
+
+	missing, extra := want.Difference(got), got.Difference(want)
+	if missing.Len() == 0 && extra.Len() == 0 {
+		return true
+	}
+
+	var parts []string
+	if missing.Len() > 0 {
+		parts = append(parts, "missing "+describe(missing))
+	}
+	if extra.Len() > 0 {
+		parts = append(parts, "extra "+describe(extra))
+	}
+
+	t.Errorf("sets differ: %s", strings.Join(parts, ", "))
+
+	return false
+}
+
+// Contains reports an error to `t` if `s` does not contain all of the given
+// values, listing the missing ones. It returns true iff `s` contains them all.
+func Contains[V comparable](t testing.TB, s *set.Set[V], v ...V) bool {
+	t.Helper()
+
+	missing := set.New(v...).Difference(s)
+	if missing.Len() == 0 {
+		return true
+	}
+
+	t.Errorf("set does not contain %s", describe(missing))
+
+	return false
+}
+
+// describe returns the number of values of `s` followed by the sorted values.
+func describe[V comparable](s *set.Set[V]) string {
+	noun := "values"
+	if s.Len() == 1 {
+		noun = "value"
+	}
+
+	return fmt.Sprintf("%d %s: %s", s.Len(), noun, s.FormatString(set.FormatOptions[V]{
+		Sorted: true,
+		Open:   "[",
+		Close:  "]",
+	}))
+}
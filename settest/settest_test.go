@@ -0,0 +1,123 @@
+package settest_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+	"github.com/micnncim/go-set/settest"
+)
+
+// recorder is a testing.TB recording the errors reported to it.
+type recorder struct {
+	testing.TB
+	errs []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errs = append(r.errs, fmt.Sprintf(format, args...))
+}
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		want     *set.Set[int]
+		got      *set.Set[int]
+		wantErrs []string
+	}{
+		{
+			name: "equal",
+			want: set.New(3, 1, 2),
+			got:  set.New(1, 2, 3),
+		},
+		{
+			name:     "missing",
+			want:     set.New(1, 2, 10, 9),
+			got:      set.New(1),
+			wantErrs: []string{"sets differ: missing 3 values: [2 9 10]"},
+		},
+		{
+			name:     "extra",
+			want:     set.New(1),
+			got:      set.New(1, 2),
+			wantErrs: []string{"sets differ: extra 1 value: [2]"},
+		},
+		{
+			name:     "missing and extra",
+			want:     set.New(1, 2, 3),
+			got:      set.New(2, 5, 4),
+			wantErrs: []string{"sets differ: missing 2 values: [1 3], extra 2 values: [4 5]"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &recorder{}
+			ok := settest.Equal(r, tt.want, tt.got)
+
+			if diff := cmp.Diff(tt.wantErrs, r.errs); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(len(tt.wantErrs) == 0, ok); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestContains(t *testing.T) {
+	t.Parallel()
+
+	type point struct{ X, Y int }
+
+	tests := []struct {
+		name     string
+		s        *set.Set[point]
+		v        []point
+		wantErrs []string
+	}{
+		{
+			name: "contains",
+			s:    set.New(point{1, 2}, point{3, 4}),
+			v:    []point{{3, 4}},
+		},
+		{
+			name: "no values",
+			s:    set.New[point](),
+		},
+		{
+			name:     "missing",
+			s:        set.New(point{1, 2}),
+			v:        []point{{5, 6}, {1, 2}, {3, 4}},
+			wantErrs: []string{"set does not contain 2 values: [{3 4} {5 6}]"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &recorder{}
+			ok := settest.Contains(r, tt.s, tt.v...)
+
+			if diff := cmp.Diff(tt.wantErrs, r.errs); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(len(tt.wantErrs) == 0, ok); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
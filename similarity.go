@@ -0,0 +1,86 @@
+package set
+
+// Jaccard returns the Jaccard index of `s` and `t`, that is the size of their
+// intersection divided by the size of their union. It returns 1 if both are
+// empty.
+//
+// For example:
+//
+//	s = {a1, a2, a3}
+//	t = {a2, a3, a4}
+//	set.Jaccard(s, t) = 0.5
+func Jaccard[V comparable](s, t *Set[V]) float64 {
+	defer rlockAll(s, t)()
+
+	n := intersectionLen(s, t)
+	u := s.size() + t.size() - n
+
+	if u == 0 {
+		return 1
+	}
+
+	return float64(n) / float64(u)
+}
+
+// Overlap returns the overlap coefficient of `s` and `t`, that is the size of
+// their intersection divided by the size of the smaller one. It returns 1 if
+// both are empty and 0 if only one is.
+//
+// For example:
+//
+//	s = {a1, a2}
+//	t = {a2, a3, a4}
+//	set.Overlap(s, t) = 0.5
+func Overlap[V comparable](s, t *Set[V]) float64 {
+	defer rlockAll(s, t)()
+
+	m := min(s.size(), t.size())
+
+	switch {
+	case s.size() == 0 && t.size() == 0:
+		return 1
+	case m == 0:
+		return 0
+	}
+
+	return float64(intersectionLen(s, t)) / float64(m)
+}
+
+// Dice returns the Sørensen–Dice coefficient of `s` and `t`, that is twice the
+// size of their intersection divided by the sum of their sizes. It returns 1 if
+// both are empty.
+//
+// For example:
+//
+//	s = {a1, a2}
+//	t = {a2, a3}
+//	set.Dice(s, t) = 0.5
+func Dice[V comparable](s, t *Set[V]) float64 {
+	defer rlockAll(s, t)()
+
+	sum := s.size() + t.size()
+
+	if sum == 0 {
+		return 1
+	}
+
+	return 2 * float64(intersectionLen(s, t)) / float64(sum)
+}
+
+// intersectionLen returns the size of the intersection of `s` and `t` by
+// walking the smaller one. Both must be locked for reading.
+func intersectionLen[V comparable](s, t *Set[V]) int {
+	walk, other := s, t
+	if t.size() < s.size() {
+		walk, other = t, s
+	}
+
+	var n int
+	for k := range walk.values() {
+		if other.contains(k) {
+			n++
+		}
+	}
+
+	return n
+}
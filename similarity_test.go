@@ -0,0 +1,135 @@
+package set_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestJaccard(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.Set[int]
+		t    *set.Set[int]
+		want float64
+	}{
+		{
+			name: "jaccard",
+			s:    set.New(1, 2, 3),
+			t:    set.New(2, 3, 4),
+			want: 0.5,
+		},
+		{
+			name: "disjoint",
+			s:    set.New(1),
+			t:    set.New(2),
+			want: 0,
+		},
+		{
+			name: "both empty",
+			s:    set.New[int](),
+			t:    set.New[int](),
+			want: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, set.Jaccard(tt.s, tt.t)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOverlap(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.Set[int]
+		t    *set.Set[int]
+		want float64
+	}{
+		{
+			name: "overlap",
+			s:    set.New(1, 2),
+			t:    set.New(2, 3, 4),
+			want: 0.5,
+		},
+		{
+			name: "subset",
+			s:    set.New(1, 2),
+			t:    set.New(1, 2, 3),
+			want: 1,
+		},
+		{
+			name: "one empty",
+			s:    set.New(1),
+			t:    set.New[int](),
+			want: 0,
+		},
+		{
+			name: "both empty",
+			s:    set.New[int](),
+			t:    set.New[int](),
+			want: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, set.Overlap(tt.s, tt.t)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDice(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    *set.Set[int]
+		t    *set.Set[int]
+		want float64
+	}{
+		{
+			name: "dice",
+			s:    set.New(1, 2),
+			t:    set.New(2, 3),
+			want: 0.5,
+		},
+		{
+			name: "both empty",
+			s:    set.New[int](),
+			t:    set.New[int](),
+			want: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, set.Dice(tt.s, tt.t)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,446 @@
+package set
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"math"
+	"os"
+	"slices"
+	"sort"
+)
+
+const (
+	// spillIndexEvery is the number of records between two entries of the
+	// in-memory index of a run.
+	spillIndexEvery = 64
+
+	// spillMergeRatio is the ratio of sizes under which two adjacent runs of
+	// a SpillSet are merged into one. Runs then grow geometrically, so that a
+	// SpillSet holds a logarithmic number of runs and each value is merged a
+	// logarithmic number of times.
+	spillMergeRatio = 2
+)
+
+// SpillSet is a set that holds up to a given number of values in memory and
+// spills the rest to temporary files, so that it can hold more values than
+// fit in memory.
+//
+// Values are spilled as runs sorted by their encoding, and lookups read a
+// single block of each run. A SpillSet must be closed to remove its files.
+//
+// Unlike Set, a SpillSet is not safe for concurrent use, even for lookups,
+// which share a read buffer.
+type SpillSet[V comparable] struct {
+	codec     Codec[V]
+	threshold int
+	dir       string
+
+	mem  *Set[V]
+	runs []*spillRun
+	n    int
+	buf  []byte
+}
+
+// spillRun is a file of distinct encoded values in increasing order, each
+// prefixed by its length as a uvarint.
+type spillRun struct {
+	f    *os.File
+	size int64
+
+	// index holds every spillIndexEvery-th key with its offset in f.
+	index []spillIndexEntry
+}
+
+type spillIndexEntry struct {
+	key []byte
+	off int64
+}
+
+// NewSpillSet returns an empty SpillSet that encodes values with `codec` and
+// spills them to files in `dir` once `threshold` values are in memory. If
+// `dir` is empty, the default directory for temporary files is used.
+//
+// NewSpillSet panics if `threshold` is less than 1.
+func NewSpillSet[V comparable](codec Codec[V], threshold int, dir string) *SpillSet[V] {
+	if threshold < 1 {
+		panic("set: spill threshold must be at least 1")
+	}
+
+	return &SpillSet[V]{
+		codec:     codec,
+		threshold: threshold,
+		dir:       dir,
+		mem:       New[V](),
+	}
+}
+
+// Contains returns true iff `s` contains a given value.
+func (s *SpillSet[V]) Contains(v V) (bool, error) {
+	if s.mem.has(v) {
+		return true, nil
+	}
+
+	s.buf = s.codec.Append(s.buf[:0], v)
+
+	return s.spilled(s.buf)
+}
+
+// Insert adds the given values to `s`.
+func (s *SpillSet[V]) Insert(v ...V) error {
+	for _, x := range v {
+		ok, err := s.Contains(x)
+		if err != nil {
+			return err
+		}
+		if ok {
+			continue
+		}
+
+		s.mem.insert(x)
+
+		if s.mem.Len() >= s.threshold {
+			if err := s.spill(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Union adds the values of `t` to `s`.
+func (s *SpillSet[V]) Union(t *SpillSet[V]) error {
+	for v, err := range t.All() {
+		if err != nil {
+			return err
+		}
+		if err := s.Insert(v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Len returns the size of `s`.
+func (s *SpillSet[V]) Len() int {
+	return s.mem.Len() + s.n
+}
+
+// All returns an iterator over the values of `s` in increasing order of their
+// encoding, merging the runs on disk with the values in memory.
+//
+// `s` must not be modified during the iteration. The iteration stops after
+// yielding an error.
+func (s *SpillSet[V]) All() iter.Seq2[V, error] {
+	return func(yield func(V, error) bool) {
+		srcs := []iter.Seq2[[]byte, error]{s.memKeys()}
+		for _, r := range s.runs {
+			srcs = append(srcs, r.keys())
+		}
+
+		for k, err := range mergeKeys(srcs) {
+			var v V
+			if err == nil {
+				v, err = s.codec.Decode(k)
+			}
+			if !yield(v, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Close removes the files of `s`. `s` must not be used after Close.
+func (s *SpillSet[V]) Close() error {
+	var errs []error
+
+	for _, r := range s.runs {
+		errs = append(errs, r.close())
+	}
+
+	s.runs = nil
+	s.n = 0
+	s.mem.Reset()
+
+	return errors.Join(errs...)
+}
+
+// spilled returns true iff `key` is in a run of `s`.
+func (s *SpillSet[V]) spilled(key []byte) (bool, error) {
+	for _, r := range s.runs {
+		ok, err := r.contains(key)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+
+	return false, nil
+}
+
+// spill writes the values in memory to a new run, then merges the last two
+// runs for as long as they are of similar sizes.
+func (s *SpillSet[V]) spill() error {
+	r, err := writeRun(s.dir, s.memKeys())
+	if err != nil {
+		return err
+	}
+
+	s.runs = append(s.runs, r)
+	s.n += s.mem.Len()
+	s.mem.Reset()
+
+	for len(s.runs) > 1 {
+		a, b := s.runs[len(s.runs)-2], s.runs[len(s.runs)-1]
+		if a.size > spillMergeRatio*b.size {
+			return nil
+		}
+
+		merged, err := writeRun(s.dir, mergeKeys([]iter.Seq2[[]byte, error]{a.keys(), b.keys()}))
+		if err != nil {
+			return err
+		}
+
+		s.runs = append(s.runs[:len(s.runs)-2], merged)
+
+		if err := errors.Join(a.close(), b.close()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// memKeys returns an iterator over the encodings of the values of `s` in
+// memory in increasing order.
+func (s *SpillSet[V]) memKeys() iter.Seq2[[]byte, error] {
+	keys := make([][]byte, 0, s.mem.Len())
+	for k := range s.mem.values() {
+		keys = append(keys, s.codec.Append(nil, k))
+	}
+
+	slices.SortFunc(keys, bytes.Compare)
+
+	return func(yield func([]byte, error) bool) {
+		for _, k := range keys {
+			if !yield(k, nil) {
+				return
+			}
+		}
+	}
+}
+
+// mergeKeys returns an iterator over the keys of all of `srcs` in increasing
+// order, given that each of them yields distinct keys in increasing order.
+// Keys found in several sources are yielded once.
+func mergeKeys(srcs []iter.Seq2[[]byte, error]) iter.Seq2[[]byte, error] {
+	return func(yield func([]byte, error) bool) {
+		type head struct {
+			key  []byte
+			next func() ([]byte, error, bool)
+		}
+
+		heads := make([]head, 0, len(srcs))
+
+		for _, src := range srcs {
+			next, stop := iter.Pull2(src)
+			defer stop()
+
+			k, err, ok := next()
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if ok {
+				heads = append(heads, head{key: k, next: next})
+			}
+		}
+
+		for len(heads) > 0 {
+			i := 0
+			for j := range heads {
+				if bytes.Compare(heads[j].key, heads[i].key) < 0 {
+					i = j
+				}
+			}
+
+			key := heads[i].key
+			if !yield(key, nil) {
+				return
+			}
+
+			for j := len(heads) - 1; j >= 0; j-- {
+				if !bytes.Equal(heads[j].key, key) {
+					continue
+				}
+
+				k, err, ok := heads[j].next()
+				if err != nil {
+					yield(nil, err)
+					return
+				}
+				if ok {
+					heads[j].key = k
+				} else {
+					heads = slices.Delete(heads, j, j+1)
+				}
+			}
+		}
+	}
+}
+
+// writeRun writes the keys yielded by `keys`, which must be distinct and in
+// increasing order, to a new temporary file in `dir`.
+func writeRun(dir string, keys iter.Seq2[[]byte, error]) (_ *spillRun, err error) {
+	f, err := os.CreateTemp(dir, "set-spill-*")
+	if err != nil {
+		return nil, fmt.Errorf("set: create run: %w", err)
+	}
+
+	r := &spillRun{f: f}
+	defer func() {
+		if err != nil {
+			r.close()
+		}
+	}()
+
+	w := bufio.NewWriter(f)
+
+	var n int
+
+	for k, err := range keys {
+		if err != nil {
+			return nil, err
+		}
+
+		if n%spillIndexEvery == 0 {
+			r.index = append(r.index, spillIndexEntry{key: bytes.Clone(k), off: r.size})
+		}
+		n++
+
+		written, err := writeRecord(w, k)
+		r.size += int64(written)
+		if err != nil {
+			return nil, fmt.Errorf("set: write run: %w", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return nil, fmt.Errorf("set: write run: %w", err)
+	}
+
+	return r, nil
+}
+
+// contains returns true iff `key` is in `r`, reading the single block of `r`
+// that may hold it.
+func (r *spillRun) contains(key []byte) (bool, error) {
+	i := sort.Search(len(r.index), func(i int) bool {
+		return bytes.Compare(r.index[i].key, key) > 0
+	}) - 1
+	if i < 0 {
+		return false, nil
+	}
+
+	end := r.size
+	if i+1 < len(r.index) {
+		end = r.index[i+1].off
+	}
+
+	block := make([]byte, end-r.index[i].off)
+	if _, err := r.f.ReadAt(block, r.index[i].off); err != nil {
+		return false, fmt.Errorf("set: read run: %w", err)
+	}
+
+	for len(block) > 0 {
+		n, w := binary.Uvarint(block)
+		if w <= 0 || uint64(len(block)-w) < n {
+			return false, errors.New("set: read run: corrupt record")
+		}
+
+		switch bytes.Compare(block[w:w+int(n)], key) {
+		case 0:
+			return true, nil
+		case 1:
+			return false, nil
+		}
+
+		block = block[w+int(n):]
+	}
+
+	return false, nil
+}
+
+// keys returns an iterator over the keys of `r` in increasing order.
+func (r *spillRun) keys() iter.Seq2[[]byte, error] {
+	return func(yield func([]byte, error) bool) {
+		br := bufio.NewReader(io.NewSectionReader(r.f, 0, r.size))
+
+		for {
+			k, _, err := readRecord(br)
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				yield(nil, fmt.Errorf("set: read run: %w", err))
+				return
+			}
+
+			if !yield(k, nil) {
+				return
+			}
+		}
+	}
+}
+
+// close closes and removes the file of `r`.
+func (r *spillRun) close() error {
+	return errors.Join(r.f.Close(), os.Remove(r.f.Name()))
+}
+
+// writeRecord writes `b` to `w` prefixed by its length as a uvarint, and
+// returns the number of bytes written.
+func writeRecord(w io.Writer, b []byte) (int, error) {
+	var header [binary.MaxVarintLen64]byte
+
+	n, err := w.Write(binary.AppendUvarint(header[:0], uint64(len(b))))
+	if err != nil {
+		return n, err
+	}
+
+	m, err := w.Write(b)
+
+	return n + m, err
+}
+
+// readRecord reads a record written by writeRecord from `r`, and returns it
+// along with the number of bytes read. It returns io.EOF only if `r` is at
+// the end of its input before the record.
+func readRecord(r *bufio.Reader) ([]byte, int, error) {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var header [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(header[:], size)
+
+	if size > math.MaxInt64 {
+		return nil, n, errors.New("set: corrupt record length")
+	}
+
+	// The length comes from the input, so the buffer only grows with the
+	// bytes actually read rather than being allocated upfront.
+	var b bytes.Buffer
+	m, err := io.CopyN(&b, r, int64(size))
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+
+	return b.Bytes(), n + int(m), err
+}
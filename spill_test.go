@@ -0,0 +1,138 @@
+package set_test
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestSpillSet(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		threshold int
+		n         int
+		maxRuns   int
+	}{
+		{
+			name:      "in memory",
+			threshold: 100,
+			n:         50,
+			maxRuns:   0,
+		},
+		{
+			name:      "few runs",
+			threshold: 100,
+			n:         500,
+			maxRuns:   3,
+		},
+		{
+			name:      "merged runs",
+			threshold: 10,
+			n:         1000,
+			maxRuns:   7,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			s := set.NewSpillSet[string](set.StringCodec{}, tt.threshold, dir)
+
+			var want []string
+			for i := 0; i < tt.n; i++ {
+				v := fmt.Sprintf("%05d", i*7%tt.n)
+				want = append(want, v)
+
+				// Insert every value twice, once after it may have
+				// been spilled.
+				if err := s.Insert(v, v); err != nil {
+					t.Fatal(err)
+				}
+				if i%2 == 1 {
+					if err := s.Insert(want[i/2]); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+			slices.Sort(want)
+
+			if files, _ := os.ReadDir(dir); len(files) > tt.maxRuns {
+				t.Errorf("got %d runs, want at most %d", len(files), tt.maxRuns)
+			}
+
+			if diff := cmp.Diff(tt.n, s.Len()); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+
+			var got []string
+			for v, err := range s.All() {
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, v)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+
+			for _, v := range []string{want[0], want[tt.n-1], "missing"} {
+				ok, err := s.Contains(v)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(v != "missing", ok); diff != "" {
+					t.Errorf("Contains(%q) (-want +got):\n%s", v, diff)
+				}
+			}
+
+			if err := s.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if files, _ := os.ReadDir(dir); len(files) > 0 {
+				t.Errorf("got %d files left after Close", len(files))
+			}
+		})
+	}
+}
+
+func TestSpillSetUnion(t *testing.T) {
+	t.Parallel()
+
+	s := set.NewSpillSet[string](set.StringCodec{}, 2, t.TempDir())
+	defer s.Close()
+	u := set.NewSpillSet[string](set.StringCodec{}, 2, t.TempDir())
+	defer u.Close()
+
+	if err := s.Insert("a", "b", "c"); err != nil {
+		t.Fatal(err)
+	}
+	if err := u.Insert("c", "d", "e"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Union(u); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for v, err := range s.All() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, v)
+	}
+	if diff := cmp.Diff([]string{"a", "b", "c", "d", "e"}, got); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
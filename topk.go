@@ -0,0 +1,186 @@
+package set
+
+import (
+	"cmp"
+	"container/heap"
+	"maps"
+	"slices"
+)
+
+// TopK tracks the most frequent values of a stream in bounded memory, using
+// the space-saving algorithm: it counts at most a given number of values, and
+// a new value replaces the least counted one, inheriting its count.
+//
+// Counts are therefore over-estimated by at most the count of the value they
+// replaced, which is reported as their error. A value occurring more than N/k
+// times in a stream of N values, with k counters, is always tracked. Unlike
+// Set, a TopK is not safe for concurrent use.
+type TopK[V comparable] struct {
+	capacity int
+	counters map[V]*topKCounter[V]
+	heap     topKHeap[V]
+}
+
+// ItemCount is a value tracked by a TopK with its estimated count.
+type ItemCount[V comparable] struct {
+	Value V
+
+	// Count is an upper bound of the number of times Value was added.
+	Count uint64
+
+	// Error is the maximum over-estimation of Count, so Value was added at
+	// least Count-Error times.
+	Error uint64
+}
+
+type topKCounter[V comparable] struct {
+	ItemCount[V]
+	index int
+}
+
+// NewTopK returns an empty TopK counting up to `capacity` values. A larger
+// capacity gives more accurate counts.
+//
+// NewTopK panics if `capacity` is less than 1.
+func NewTopK[V comparable](capacity int) *TopK[V] {
+	if capacity < 1 {
+		panic("set: top-k capacity must be at least 1")
+	}
+
+	return &TopK[V]{
+		capacity: capacity,
+		counters: make(map[V]*topKCounter[V], capacity),
+	}
+}
+
+// Add counts one occurrence of `v`.
+func (t *TopK[V]) Add(v V) {
+	t.add(v, 1, 0)
+}
+
+// AddAll counts one occurrence of each value of `s`, for example to find the
+// values found in the most sets.
+func (t *TopK[V]) AddAll(s *Set[V]) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for k := range s.values() {
+		t.add(k, 1, 0)
+	}
+}
+
+// Top returns up to `k` tracked values with the highest counts, in decreasing
+// order of count.
+func (t *TopK[V]) Top(k int) []ItemCount[V] {
+	top := make([]ItemCount[V], 0, len(t.heap))
+	for _, c := range t.heap {
+		top = append(top, c.ItemCount)
+	}
+
+	slices.SortFunc(top, func(a, b ItemCount[V]) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Error, b.Error)
+	})
+
+	return top[:min(max(k, 0), len(top))]
+}
+
+// Merge adds the counts of `u` to `t`, as if the values added to `u` had been
+// added to `t`, with the same error bounds.
+//
+// A value tracked by only one of them may have been added to the other up to
+// as many times as the lowest count of the other, if it is full, which is
+// added to both its count and its error.
+func (t *TopK[V]) Merge(u *TopK[V]) {
+	tMin, uMin := t.floor(), u.floor()
+
+	counts := make(map[V]ItemCount[V], len(t.counters)+len(u.counters))
+	for v, c := range t.counters {
+		counts[v] = ItemCount[V]{Value: v, Count: c.Count + uMin, Error: c.Error + uMin}
+	}
+	for v, c := range u.counters {
+		if m, ok := counts[v]; ok {
+			m.Count += c.Count - uMin
+			m.Error += c.Error - uMin
+			counts[v] = m
+		} else {
+			counts[v] = ItemCount[V]{Value: v, Count: c.Count + tMin, Error: c.Error + tMin}
+		}
+	}
+
+	merged := slices.SortedFunc(maps.Values(counts), func(a, b ItemCount[V]) int {
+		return cmp.Compare(b.Count, a.Count)
+	})
+
+	clear(t.counters)
+	t.heap = t.heap[:0]
+
+	for _, c := range merged[:min(t.capacity, len(merged))] {
+		t.add(c.Value, c.Count, c.Error)
+	}
+}
+
+// add adds `n` occurrences of `v` with the given error, replacing the least
+// counted value if `t` is full.
+func (t *TopK[V]) add(v V, n, err uint64) {
+	if c, ok := t.counters[v]; ok {
+		c.Count += n
+		c.Error += err
+		heap.Fix(&t.heap, c.index)
+		return
+	}
+
+	if len(t.heap) < t.capacity {
+		c := &topKCounter[V]{ItemCount: ItemCount[V]{Value: v, Count: n, Error: err}}
+		t.counters[v] = c
+		heap.Push(&t.heap, c)
+		return
+	}
+
+	c := t.heap[0]
+	delete(t.counters, c.Value)
+
+	c.Value = v
+	c.Error = c.Count + err
+	c.Count += n
+
+	t.counters[v] = c
+	heap.Fix(&t.heap, 0)
+}
+
+// floor returns the lowest count of `t` if it is full, and zero otherwise,
+// which bounds the count of the values it does not track.
+func (t *TopK[V]) floor() uint64 {
+	if len(t.heap) < t.capacity {
+		return 0
+	}
+
+	return t.heap[0].Count
+}
+
+// topKHeap is a min-heap of counters by count.
+type topKHeap[V comparable] []*topKCounter[V]
+
+func (h topKHeap[V]) Len() int           { return len(h) }
+func (h topKHeap[V]) Less(i, j int) bool { return h[i].Count < h[j].Count }
+
+func (h topKHeap[V]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *topKHeap[V]) Push(x any) {
+	c := x.(*topKCounter[V])
+	c.index = len(*h)
+	*h = append(*h, c)
+}
+
+func (h *topKHeap[V]) Pop() any {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
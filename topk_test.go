@@ -0,0 +1,115 @@
+package set_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestTopK(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		capacity int
+		add      []string
+		k        int
+		want     []set.ItemCount[string]
+	}{
+		{
+			name:     "empty",
+			capacity: 2,
+			k:        1,
+			want:     []set.ItemCount[string]{},
+		},
+		{
+			name:     "exact counts",
+			capacity: 3,
+			add:      []string{"a", "b", "a", "c", "a", "b"},
+			k:        2,
+			want: []set.ItemCount[string]{
+				{Value: "a", Count: 3},
+				{Value: "b", Count: 2},
+			},
+		},
+		{
+			name:     "replaced values",
+			capacity: 2,
+			add:      []string{"a", "a", "a", "b", "c", "a"},
+			k:        5,
+			want: []set.ItemCount[string]{
+				{Value: "a", Count: 4},
+				{Value: "c", Count: 2, Error: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := set.NewTopK[string](tt.capacity)
+			for _, v := range tt.add {
+				s.Add(v)
+			}
+
+			if diff := cmp.Diff(tt.want, s.Top(tt.k)); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTopKHeavyHitters(t *testing.T) {
+	t.Parallel()
+
+	// Values 0 to 4 make up half of the stream, and the others are seen
+	// once each.
+	s := set.NewTopK[int](20)
+	for i := 0; i < 10000; i++ {
+		if i%2 == 0 {
+			s.Add(i / 2 % 5)
+		} else {
+			s.Add(i + 100)
+		}
+	}
+
+	got := set.New[int]()
+	for _, c := range s.Top(5) {
+		got.Insert(c.Value)
+		if c.Count-c.Error > 1000 || c.Count < 1000 {
+			t.Errorf("got count %d with error %d for %d, which was added 1000 times", c.Count, c.Error, c.Value)
+		}
+	}
+
+	if diff := cmp.Diff(set.New(0, 1, 2, 3, 4), got, cmp.Comparer(equal(t))); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
+
+func TestTopKMerge(t *testing.T) {
+	t.Parallel()
+
+	s := set.NewTopK[string](2)
+	s.AddAll(set.New("a", "b"))
+	s.Add("a")
+
+	u := set.NewTopK[string](2)
+	u.AddAll(set.New("a", "c"))
+	u.Add("c")
+	u.Add("c")
+
+	s.Merge(u)
+
+	want := []set.ItemCount[string]{
+		{Value: "c", Count: 4, Error: 1},
+		{Value: "a", Count: 3},
+	}
+	if diff := cmp.Diff(want, s.Top(2)); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
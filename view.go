@@ -0,0 +1,173 @@
+package set
+
+import (
+	"iter"
+)
+
+// View is a read-only set of comparables.
+//
+// Set implements View, as do UnionView, IntersectionView and DifferenceView,
+// so views can be composed over sets and other views.
+type View[V comparable] interface {
+	// Contains returns true iff the view contains a given value.
+	Contains(v V) bool
+	// Len returns the size of the view.
+	Len() int
+	// All returns an iterator over the values of the view.
+	All() iter.Seq[V]
+}
+
+var (
+	_ View[int] = (*Set[int])(nil)
+	_ View[int] = (*UnionView[int])(nil)
+	_ View[int] = (*IntersectionView[int])(nil)
+	_ View[int] = (*DifferenceView[int])(nil)
+)
+
+// UnionView is a View of the union of views, which is computed on demand from
+// the underlying live views without copying their values.
+type UnionView[V comparable] struct {
+	views []View[V]
+}
+
+// NewUnionView returns a UnionView of the given views.
+func NewUnionView[V comparable](views ...View[V]) *UnionView[V] {
+	return &UnionView[V]{views}
+}
+
+// Contains returns true iff any of the views of `u` contains a given value.
+func (u *UnionView[V]) Contains(v V) bool {
+	for _, w := range u.views {
+		if w.Contains(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Len returns the size of `u`. It iterates over all the values of `u`.
+func (u *UnionView[V]) Len() int {
+	return count(u.All())
+}
+
+// All returns an iterator over the values of `u`. Values in several views are
+// yielded once.
+func (u *UnionView[V]) All() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for i, w := range u.views {
+			seen := NewUnionView(u.views[:i]...)
+
+			for k := range w.All() {
+				if !seen.Contains(k) && !yield(k) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// IntersectionView is a View of the intersection of views, which is computed
+// on demand from the underlying live views without copying their values.
+type IntersectionView[V comparable] struct {
+	views []View[V]
+}
+
+// NewIntersectionView returns an IntersectionView of the given views. An
+// IntersectionView of no views is empty.
+func NewIntersectionView[V comparable](views ...View[V]) *IntersectionView[V] {
+	return &IntersectionView[V]{views}
+}
+
+// Contains returns true iff all the views of `u` contain a given value.
+func (u *IntersectionView[V]) Contains(v V) bool {
+	for _, w := range u.views {
+		if !w.Contains(v) {
+			return false
+		}
+	}
+
+	return len(u.views) > 0
+}
+
+// Len returns the size of `u`. It iterates over all the values of `u`.
+func (u *IntersectionView[V]) Len() int {
+	return count(u.All())
+}
+
+// All returns an iterator over the values of `u`, walking the smallest view.
+func (u *IntersectionView[V]) All() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		if len(u.views) == 0 {
+			return
+		}
+
+		walk, n := u.views[0], u.views[0].Len()
+		for _, w := range u.views[1:] {
+			if m := w.Len(); m < n {
+				walk, n = w, m
+			}
+		}
+
+		for k := range walk.All() {
+			if u.Contains(k) && !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// DifferenceView is a View of the values of a view that are not in any of
+// other views, which is computed on demand from the underlying live views
+// without copying their values.
+type DifferenceView[V comparable] struct {
+	base     View[V]
+	subtract []View[V]
+}
+
+// NewDifferenceView returns a DifferenceView of the values of `base` that are
+// not in any of the `subtract` views.
+func NewDifferenceView[V comparable](base View[V], subtract ...View[V]) *DifferenceView[V] {
+	return &DifferenceView[V]{base, subtract}
+}
+
+// Contains returns true iff the base view of `u` contains a given value and
+// none of the subtracted views do.
+func (u *DifferenceView[V]) Contains(v V) bool {
+	if !u.base.Contains(v) {
+		return false
+	}
+
+	for _, w := range u.subtract {
+		if w.Contains(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Len returns the size of `u`. It iterates over all the values of `u`.
+func (u *DifferenceView[V]) Len() int {
+	return count(u.All())
+}
+
+// All returns an iterator over the values of `u`.
+func (u *DifferenceView[V]) All() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for k := range u.base.All() {
+			if u.Contains(k) && !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+func count[V any](seq iter.Seq[V]) int {
+	var n int
+	for range seq {
+		n++
+	}
+
+	return n
+}
@@ -0,0 +1,171 @@
+package set_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestUnionView(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		views     []set.View[int]
+		v         int
+		want      []int
+		wantMatch bool
+	}{
+		{
+			name:      "union view",
+			views:     []set.View[int]{set.New(1, 2), set.New(2, 3), set.New(3, 4)},
+			v:         3,
+			want:      []int{1, 2, 3, 4},
+			wantMatch: true,
+		},
+		{
+			name:      "empty",
+			v:         1,
+			want:      []int{},
+			wantMatch: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			u := set.NewUnionView(tt.views...)
+
+			assertView(t, u, tt.v, tt.want, tt.wantMatch)
+		})
+	}
+}
+
+func TestIntersectionView(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		views     []set.View[int]
+		v         int
+		want      []int
+		wantMatch bool
+	}{
+		{
+			name:      "intersection view",
+			views:     []set.View[int]{set.New(1, 2, 3), set.New(2, 3, 4), set.New(3, 2)},
+			v:         2,
+			want:      []int{2, 3},
+			wantMatch: true,
+		},
+		{
+			name:      "empty",
+			v:         1,
+			want:      []int{},
+			wantMatch: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			u := set.NewIntersectionView(tt.views...)
+
+			assertView(t, u, tt.v, tt.want, tt.wantMatch)
+		})
+	}
+}
+
+func TestDifferenceView(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		base      set.View[int]
+		subtract  []set.View[int]
+		v         int
+		want      []int
+		wantMatch bool
+	}{
+		{
+			name:      "difference view",
+			base:      set.New(1, 2, 3, 4),
+			subtract:  []set.View[int]{set.New(1), set.New(3, 5)},
+			v:         3,
+			want:      []int{2, 4},
+			wantMatch: false,
+		},
+		{
+			name: "nested views",
+			base: set.NewUnionView[int](set.New(1, 2), set.New(3)),
+			subtract: []set.View[int]{
+				set.NewIntersectionView[int](set.New(1, 2), set.New(2, 3)),
+			},
+			v:         3,
+			want:      []int{1, 3},
+			wantMatch: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			u := set.NewDifferenceView(tt.base, tt.subtract...)
+
+			assertView(t, u, tt.v, tt.want, tt.wantMatch)
+		})
+	}
+}
+
+func TestViewLive(t *testing.T) {
+	t.Parallel()
+
+	s, u := set.New(1), set.New(2)
+	view := set.NewUnionView[int](s, u)
+
+	s.Insert(3)
+	u.Delete(2)
+
+	if diff := cmp.Diff([]int{1, 3}, collectValues(view), cmpopts.SortSlices(func(i, j int) bool {
+		return i < j
+	})); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
+
+func assertView(t *testing.T, u set.View[int], v int, want []int, wantMatch bool) {
+	t.Helper()
+
+	if diff := cmp.Diff(want, collectValues(u), cmpopts.SortSlices(func(i, j int) bool {
+		return i < j
+	})); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(len(want), u.Len()); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(wantMatch, u.Contains(v)); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
+
+func collectValues(u set.View[int]) []int {
+	v := []int{}
+	for k := range u.All() {
+		v = append(v, k)
+	}
+
+	return v
+}
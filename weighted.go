@@ -0,0 +1,277 @@
+package set
+
+import (
+	"cmp"
+	"iter"
+	"math"
+	"math/bits"
+	"math/rand/v2"
+	"slices"
+)
+
+// WeightedSet is a set whose values carry non-negative weights, from which
+// values can be sampled with probabilities proportional to their weights.
+//
+// Weights are kept in a Fenwick tree, so that inserting, deleting, updating
+// the weight of a value and sampling a value all take logarithmic time. Unlike
+// Set, a WeightedSet is not safe for concurrent use.
+type WeightedSet[V comparable] struct {
+	index   map[V]int
+	values  []V
+	weights []float64
+
+	// tree is a Fenwick tree over weights, where tree[i] holds the sum of
+	// the weights at the positions in (i-i&-i, i], counted from 1.
+	tree []float64
+
+	// positive is the number of values with a positive weight.
+	positive int
+}
+
+// NewWeightedSet returns an empty WeightedSet.
+func NewWeightedSet[V comparable]() *WeightedSet[V] {
+	return &WeightedSet[V]{
+		index: make(map[V]int),
+		tree:  []float64{0},
+	}
+}
+
+// Insert adds `v` to `s` with the given weight, or updates the weight of `v`
+// if it is already in `s`. A value of zero weight is in `s` but never sampled.
+//
+// Insert panics if `weight` is negative, infinite or NaN.
+func (s *WeightedSet[V]) Insert(v V, weight float64) {
+	if weight < 0 || math.IsInf(weight, 0) || math.IsNaN(weight) {
+		panic("set: weight must be non-negative and finite")
+	}
+
+	if i, ok := s.index[v]; ok {
+		s.setWeight(i, weight)
+		return
+	}
+
+	s.index[v] = len(s.values)
+	s.values = append(s.values, v)
+	s.weights = append(s.weights, 0)
+
+	// The new node covers its own weight and the values before it in its
+	// range, which are found from prefix sums.
+	n := len(s.values)
+	s.tree = append(s.tree, s.prefix(n-1)-s.prefix(n-n&-n))
+	s.setWeight(n-1, weight)
+}
+
+// Delete removes `v` from `s`, and reports whether it was present.
+func (s *WeightedSet[V]) Delete(v V) bool {
+	i, ok := s.index[v]
+	if !ok {
+		return false
+	}
+
+	// Move the last value to the position of `v`, then drop the last
+	// position, whose node no other node covers.
+	last := len(s.values) - 1
+	w := s.weights[last]
+
+	s.setWeight(last, 0)
+	if i != last {
+		s.setWeight(i, w)
+		s.values[i] = s.values[last]
+		s.index[s.values[i]] = i
+	}
+
+	delete(s.index, v)
+	s.values = s.values[:last]
+	s.weights = s.weights[:last]
+	s.tree = s.tree[:last+1]
+
+	return true
+}
+
+// Contains returns true iff `s` contains `v`.
+func (s *WeightedSet[V]) Contains(v V) bool {
+	_, ok := s.index[v]
+	return ok
+}
+
+// Weight returns the weight of `v`, and false if `v` is not in `s`.
+func (s *WeightedSet[V]) Weight(v V) (float64, bool) {
+	i, ok := s.index[v]
+	if !ok {
+		return 0, false
+	}
+
+	return s.weights[i], true
+}
+
+// TotalWeight returns the sum of the weights of the values of `s`.
+func (s *WeightedSet[V]) TotalWeight() float64 {
+	return s.prefix(len(s.values))
+}
+
+// Len returns the number of values in `s`.
+func (s *WeightedSet[V]) Len() int {
+	return len(s.values)
+}
+
+// All returns an iterator over the values of `s` with their weights. `s` must
+// not be modified during the iteration.
+func (s *WeightedSet[V]) All() iter.Seq2[V, float64] {
+	return func(yield func(V, float64) bool) {
+		for i, v := range s.values {
+			if !yield(v, s.weights[i]) {
+				return
+			}
+		}
+	}
+}
+
+// ToSet returns a Set of the values of `s`.
+func (s *WeightedSet[V]) ToSet() *Set[V] {
+	t := newSized[V](len(s.values))
+
+	for _, v := range s.values {
+		t.insert(v)
+	}
+
+	return t
+}
+
+// SampleWeighted returns up to `n` distinct values of `s` drawn at random
+// without replacement: each value is drawn among the values not drawn yet
+// with a probability proportional to its weight, so heavier values tend to
+// come first.
+//
+// Fewer than `n` values are returned if fewer have a positive weight.
+func (s *WeightedSet[V]) SampleWeighted(n int) []V {
+	n = min(n, s.positive)
+	if n <= 0 {
+		return nil
+	}
+
+	v := make([]V, 0, n)
+	drawn := make(map[int]struct{}, n)
+
+	// Values are drawn from the whole tree, which is left untouched, and
+	// drawn again when already drawn. This keeps the probabilities of the
+	// values not drawn yet proportional to their weights, as long as most
+	// of the weight is not drawn yet, so that few draws are missed.
+	total := s.prefix(len(s.values))
+
+	var drawnWeight float64
+	for misses := 0; len(v) < n && drawnWeight < total/2 && misses < sampleMaxMisses; {
+		// Rounding errors in the tree may also land on a value of zero
+		// weight, or past the last position.
+		i := s.draw(total)
+		if _, ok := drawn[i]; ok || i == len(s.values) || s.weights[i] == 0 {
+			misses++
+			continue
+		}
+
+		drawn[i] = struct{}{}
+		drawnWeight += s.weights[i]
+		v = append(v, s.values[i])
+		misses = 0
+	}
+
+	if len(v) == n {
+		return v
+	}
+
+	// Draw the remaining values exactly by giving each value not drawn yet
+	// an exponential random key of rate its weight, then taking the values
+	// of the smallest keys, which come first with the same probabilities.
+	type key struct {
+		i int
+		k float64
+	}
+
+	keys := make([]key, 0, s.positive-len(v))
+	for i, w := range s.weights {
+		if _, ok := drawn[i]; !ok && w > 0 {
+			keys = append(keys, key{i: i, k: rand.ExpFloat64() / w})
+		}
+	}
+
+	slices.SortFunc(keys, func(a, b key) int {
+		return cmp.Compare(a.k, b.k)
+	})
+
+	for _, k := range keys[:n-len(v)] {
+		v = append(v, s.values[k.i])
+	}
+
+	return v
+}
+
+// sampleMaxMisses is the number of draws from the tree in a row that may miss
+// before SampleWeighted draws the remaining values exactly.
+const sampleMaxMisses = 16
+
+// draw returns the position of a value drawn with a probability proportional
+// to its weight in the tree, whose weights sum to `total`, or len(s.values)
+// if rounding errors get past the last position.
+func (s *WeightedSet[V]) draw(total float64) int {
+	x := rand.Float64() * total
+
+	// Descend the tree to the first position whose prefix sum exceeds x.
+	i := 0
+	for step := 1 << (bits.Len(uint(len(s.values))) - 1); step > 0; step >>= 1 {
+		if j := i + step; j < len(s.tree) && s.tree[j] <= x {
+			i = j
+			x -= s.tree[j]
+		}
+	}
+
+	return i
+}
+
+// setWeight sets the weight of position `i`.
+func (s *WeightedSet[V]) setWeight(i int, w float64) {
+	old := s.weights[i]
+
+	switch {
+	case old == 0 && w > 0:
+		s.positive++
+	case old > 0 && w == 0:
+		s.positive--
+	}
+
+	s.updateTree(i, w-old)
+	s.weights[i] = w
+
+	// Subtracting a weight much larger than the remaining ones loses the
+	// precision of the sums holding them, so the tree is rebuilt.
+	if w < old && old > s.prefix(len(s.values))*0x1p20 {
+		s.rebuild()
+	}
+}
+
+// rebuild recomputes the tree from the weights.
+func (s *WeightedSet[V]) rebuild() {
+	for i, w := range s.weights {
+		s.tree[i+1] = w
+	}
+	for i := 1; i < len(s.tree); i++ {
+		if j := i + i&-i; j < len(s.tree) {
+			s.tree[j] += s.tree[i]
+		}
+	}
+}
+
+// updateTree adds `delta` to the weight of position `i` in the tree.
+func (s *WeightedSet[V]) updateTree(i int, delta float64) {
+	for j := i + 1; j < len(s.tree); j += j & -j {
+		s.tree[j] += delta
+	}
+}
+
+// prefix returns the sum of the weights of the first `n` positions.
+func (s *WeightedSet[V]) prefix(n int) float64 {
+	var sum float64
+	for j := n; j > 0; j -= j & -j {
+		sum += s.tree[j]
+	}
+
+	return sum
+}
@@ -0,0 +1,187 @@
+package set_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/micnncim/go-set"
+)
+
+func TestWeightedSet(t *testing.T) {
+	t.Parallel()
+
+	s := set.NewWeightedSet[string]()
+	s.Insert("a", 1)
+	s.Insert("b", 2)
+	s.Insert("c", 4)
+	s.Insert("d", 8)
+
+	s.Insert("b", 0.5)
+	s.Delete("a")
+	s.Delete("missing")
+
+	if diff := cmp.Diff(12.5, s.TotalWeight()); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(3, s.Len()); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+
+	weights := make(map[string]float64)
+	for v, w := range s.All() {
+		weights[v] = w
+	}
+	if diff := cmp.Diff(map[string]float64{"b": 0.5, "c": 4, "d": 8}, weights); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+
+	if w, ok := s.Weight("c"); w != 4 || !ok {
+		t.Errorf("got weight %v, %v, want 4, true", w, ok)
+	}
+	if _, ok := s.Weight("a"); ok {
+		t.Error("got the weight of a deleted value")
+	}
+	if !s.ToSet().Equal(set.New("b", "c", "d")) {
+		t.Errorf("got values %v", s.ToSet())
+	}
+}
+
+func TestWeightedSetSampleWeighted(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		weights map[int]float64
+		n       int
+		want    []int
+	}{
+		{
+			name:    "empty",
+			weights: map[int]float64{},
+			n:       2,
+			want:    nil,
+		},
+		{
+			name:    "zero weights",
+			weights: map[int]float64{1: 0, 2: 3, 3: 0},
+			n:       2,
+			want:    []int{2},
+		},
+		{
+			name:    "all values",
+			weights: map[int]float64{1: 1, 2: 2, 3: 3},
+			n:       5,
+			want:    []int{1, 2, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := set.NewWeightedSet[int]()
+			for v, w := range tt.weights {
+				s.Insert(v, w)
+			}
+
+			for i := 0; i < 100; i++ {
+				got := s.SampleWeighted(tt.n)
+				slices.Sort(got)
+
+				if diff := cmp.Diff(tt.want, got); diff != "" {
+					t.Fatalf("(-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestWeightedSetSampleWeightedDistribution(t *testing.T) {
+	t.Parallel()
+
+	s := set.NewWeightedSet[int]()
+	for i := 0; i < 100; i++ {
+		s.Insert(i, 0)
+	}
+	s.Insert(1, 1)
+	s.Insert(2, 3)
+	for i := 3; i < 100; i++ {
+		s.Delete(i)
+	}
+
+	const n = 20000
+
+	var first int
+	for i := 0; i < n; i++ {
+		if s.SampleWeighted(1)[0] == 2 {
+			first++
+		}
+	}
+
+	// 2 is drawn with a probability of 3/4, with a standard deviation of
+	// about 0.003 over n draws.
+	if got := float64(first) / n; math.Abs(got-0.75) > 0.03 {
+		t.Errorf("got 2 drawn with a frequency of %v, want 0.75", got)
+	}
+}
+
+func TestWeightedSetSampleWeightedSkewed(t *testing.T) {
+	t.Parallel()
+
+	s := set.NewWeightedSet[string]()
+	s.Insert("a", 1e20)
+	s.Insert("b", 1)
+	s.Insert("c", 1)
+
+	const n = 2000
+
+	var second int
+	for i := 0; i < n; i++ {
+		got := s.SampleWeighted(3)
+		if len(got) != 3 {
+			t.Fatalf("got %v, want 3 values", got)
+		}
+		if got[0] == "a" && got[1] == "b" {
+			second++
+		}
+	}
+
+	// a is drawn first, then b or c with a probability of 1/2 each, with a
+	// standard deviation of about 0.011 over n draws.
+	if got := float64(second) / n; math.Abs(got-0.5) > 0.06 {
+		t.Errorf("got b drawn second with a frequency of %v, want 0.5", got)
+	}
+
+	s.Delete("a")
+
+	if diff := cmp.Diff(2.0, s.TotalWeight()); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+
+	got := s.SampleWeighted(2)
+	slices.Sort(got)
+	if diff := cmp.Diff([]string{"b", "c"}, got); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
+
+func TestWeightedSetInsertPanic(t *testing.T) {
+	t.Parallel()
+
+	for _, w := range []float64{-1, math.Inf(1), math.NaN()} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("got no panic inserting a weight of %v", w)
+				}
+			}()
+
+			set.NewWeightedSet[int]().Insert(1, w)
+		}()
+	}
+}